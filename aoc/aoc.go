@@ -0,0 +1,179 @@
+// Package aoc holds the registry of every day's solution, so that they can all be run from a single binary.
+package aoc
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+var ErrNoSuchDay = errors.New("no solution registered for day")
+var ErrNoSuchPart = errors.New("day has no such part")
+var ErrNoSuchCommand = errors.New("day has no such command")
+
+// Command is a day-specific command, usually for debugging. It is given the parsed input, any arguments
+// that follow the command's name, and the writer to print its output to.
+type Command[T any] func(parsed T, args []string, out io.Writer) error
+
+// Solution holds the entry points for a single day's puzzle. T is the type of the parsed input, and A is the type
+// of the answers. Either part may be nil, if the day cannot solve that part on its own. A part returns an error if
+// the input has no answer it can find. Parts that may take a while should check their context as they go, and return
+// its error (e.g. context.DeadlineExceeded) if it is done before they are.
+type Solution[T any, A any] struct {
+	// Parse parses the puzzle input, which has already had its surrounding whitespace removed
+	Parse func(input string) (T, error)
+	Part1 func(context.Context, T) (A, error)
+	Part2 func(context.Context, T) (A, error)
+	// Flags, if non-nil, registers any of the day's own options on the given flag set. It may be called more than
+	// once, and must reset the options to their defaults each time.
+	Flags func(*flag.FlagSet)
+	// Commands holds any extra commands the day provides, keyed by name
+	Commands map[string]Command[T]
+}
+
+// Solver is a Solution with its types erased, so that every day can be stored in the registry together
+type Solver interface {
+	// Parse parses the puzzle input, which has already had its surrounding whitespace removed
+	Parse(input string) (any, error)
+	// HasPart indicates whether or not the given part can be run
+	HasPart(part int) bool
+	// RunPart runs the given part against the result of Parse, stopping early if the context is done
+	RunPart(ctx context.Context, part int, parsed any) (any, error)
+	// RegisterFlags registers any of the day's own options on the given flag set
+	RegisterFlags(flagSet *flag.FlagSet)
+	// CommandNames gets the names of all of the day's extra commands, in sorted order
+	CommandNames() []string
+	// RunCommand runs the named command against the result of Parse
+	RunCommand(name string, parsed any, args []string, out io.Writer) error
+}
+
+type solver[T any, A any] struct {
+	solution Solution[T, A]
+}
+
+var registry = map[int]Solver{}
+
+// Register adds the given day's solution to the registry. Panics if the day has already been registered, or if the
+// solution has no Parse function.
+func Register[T any, A any](day int, solution Solution[T, A]) {
+	if _, ok := registry[day]; ok {
+		panic(fmt.Sprintf("aoc: day %d registered twice", day))
+	} else if solution.Parse == nil {
+		panic(fmt.Sprintf("aoc: day %d registered without a Parse function", day))
+	}
+
+	registry[day] = solver[T, A]{solution: solution}
+}
+
+// Lookup gets the solver for the given day
+func Lookup(day int) (Solver, error) {
+	s, ok := registry[day]
+	if !ok {
+		return nil, fmt.Errorf("%w %d", ErrNoSuchDay, day)
+	}
+
+	return s, nil
+}
+
+// Days gets every registered day, in sorted order
+func Days() []int {
+	return sortedKeys(registry)
+}
+
+// ReadInput reads the puzzle input from the given file, with its surrounding whitespace removed
+func ReadInput(filename string) (string, error) {
+	inputFile, err := os.Open(filename)
+	if err != nil {
+		return "", fmt.Errorf("open input file: %w", err)
+	}
+
+	defer inputFile.Close()
+
+	inputBytes, err := io.ReadAll(inputFile)
+	if err != nil {
+		return "", fmt.Errorf("read input file: %w", err)
+	}
+
+	return strings.TrimSpace(string(inputBytes)), nil
+}
+
+func (s solver[T, A]) Parse(input string) (any, error) {
+	return s.solution.Parse(input)
+}
+
+func (s solver[T, A]) HasPart(part int) bool {
+	return s.partFunc(part) != nil
+}
+
+func (s solver[T, A]) RunPart(ctx context.Context, part int, parsed any) (any, error) {
+	partFunc := s.partFunc(part)
+	if partFunc == nil {
+		return nil, fmt.Errorf("%w %d", ErrNoSuchPart, part)
+	}
+
+	typedParsed, ok := parsed.(T)
+	if !ok {
+		// Programmer error; this can only be given the result of Parse
+		panic(fmt.Sprintf("aoc: parsed input has type %T, not %T", parsed, *new(T)))
+	}
+
+	answer, err := partFunc(ctx, typedParsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return answer, nil
+}
+
+func (s solver[T, A]) RegisterFlags(flagSet *flag.FlagSet) {
+	if s.solution.Flags != nil {
+		s.solution.Flags(flagSet)
+	}
+}
+
+func (s solver[T, A]) CommandNames() []string {
+	return sortedKeys(s.solution.Commands)
+}
+
+func (s solver[T, A]) RunCommand(name string, parsed any, args []string, out io.Writer) error {
+	command, ok := s.solution.Commands[name]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrNoSuchCommand, name)
+	}
+
+	typedParsed, ok := parsed.(T)
+	if !ok {
+		// Programmer error; this can only be given the result of Parse
+		panic(fmt.Sprintf("aoc: parsed input has type %T, not %T", parsed, *new(T)))
+	}
+
+	return command(typedParsed, args, out)
+}
+
+func (s solver[T, A]) partFunc(part int) func(context.Context, T) (A, error) {
+	switch part {
+	case 1:
+		return s.solution.Part1
+	case 2:
+		return s.solution.Part2
+	default:
+		return nil
+	}
+}
+
+func sortedKeys[T cmp.Ordered, U any](m map[T]U) []T {
+	keys := make([]T, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+)
+
+var errUnknownFormat = errors.New("unknown format")
+
+// benchReport holds the timings from a single run of bench, along with enough about the build to compare it with
+// runs from other commits
+type benchReport struct {
+	// Revision is the commit the binary was built from, if it was built from a checkout
+	Revision  string        `json:"revision,omitempty"`
+	Modified  bool          `json:"modified"`
+	GoVersion string        `json:"goVersion"`
+	Time      time.Time     `json:"time"`
+	Results   []benchResult `json:"results"`
+}
+
+// benchResult holds the timings of one stage (parse, part1, or part2) of a day, against a single input
+type benchResult struct {
+	Day    int           `json:"day"`
+	Input  string        `json:"input"`
+	Stage  string        `json:"stage"`
+	Runs   int           `json:"runs"`
+	Min    time.Duration `json:"minNs"`
+	Median time.Duration `json:"medianNs"`
+	Mean   time.Duration `json:"meanNs"`
+}
+
+// benchCommand times parsing and each part of every day, against every input in a directory (laid out as it is for
+// verify), and prints the timings as a table or as JSON
+func benchCommand(args []string, out io.Writer) error {
+	flagSet := flag.NewFlagSet("bench", flag.ExitOnError)
+	day := flagSet.Int("day", 0, "the day to benchmark (default: every day with an input)")
+	inputsDir := flagSet.String("inputs", "inputs", "the directory holding the inputs, laid out as it is for verify")
+	count := flagSet.Int("count", 5, "the number of times to run each stage")
+	format := flagSet.String("format", "table", "the output format, either table or json")
+	flagSet.Parse(args)
+
+	if *count < 1 {
+		return fmt.Errorf("count must be at least 1, not %d", *count)
+	} else if *format != "table" && *format != "json" {
+		return fmt.Errorf("%w %q", errUnknownFormat, *format)
+	}
+
+	inputs, err := findStoredInputs(*inputsDir)
+	if err != nil {
+		return err
+	}
+
+	report := newBenchReport()
+	// A failing input should not stop the others from being timed, so we report every failure once we're done
+	inputErrs := []error{}
+	for _, input := range inputs {
+		if *day != 0 && input.day != *day {
+			continue
+		}
+
+		results, err := benchInput(input, *count)
+		if err != nil {
+			inputErrs = append(inputErrs, fmt.Errorf("day %d %s: %w", input.day, input.path, err))
+			continue
+		}
+
+		report.Results = append(report.Results, results...)
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("encode report: %w", err)
+		}
+	} else {
+		printBenchTable(out, report.Results)
+	}
+
+	return errors.Join(inputErrs...)
+}
+
+func newBenchReport() benchReport {
+	report := benchReport{
+		GoVersion: runtime.Version(),
+		Time:      time.Now().UTC(),
+		Results:   []benchResult{},
+	}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return report
+	}
+
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			report.Revision = setting.Value
+		case "vcs.modified":
+			report.Modified = setting.Value == "true"
+		}
+	}
+
+	return report
+}
+
+// benchInput times parsing the input, and each part of its day, the given number of times. Every part is given a
+// freshly parsed input, in case an earlier one changed it.
+func benchInput(input storedInput, count int) ([]benchResult, error) {
+	solver, err := aoc.Lookup(input.day)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like verify, timings are only comparable with the day's default options
+	solver.RegisterFlags(flag.NewFlagSet(fmt.Sprintf("day %d", input.day), flag.ContinueOnError))
+
+	rawInput, err := aoc.ReadInput(input.path)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := runnableParts(solver)
+	parseTimes := make([]time.Duration, 0, count)
+	partTimes := map[int][]time.Duration{}
+	for i := 0; i < count; i++ {
+		start := time.Now()
+		parsed, err := parseInput(solver, rawInput, input.path)
+		if err != nil {
+			return nil, err
+		}
+
+		parseTimes = append(parseTimes, time.Since(start))
+		for j, part := range parts {
+			if j > 0 {
+				parsed, err = parseInput(solver, rawInput, input.path)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			start := time.Now()
+			if _, err := solver.RunPart(context.Background(), part, parsed); err != nil {
+				return nil, fmt.Errorf("part %d: %w", part, err)
+			}
+
+			partTimes[part] = append(partTimes[part], time.Since(start))
+		}
+	}
+
+	results := []benchResult{summarizeTimes(input, "parse", parseTimes)}
+	for _, part := range parts {
+		results = append(results, summarizeTimes(input, fmt.Sprintf("part%d", part), partTimes[part]))
+	}
+
+	return results, nil
+}
+
+// summarizeTimes summarizes the times a stage took to run. There must be at least one.
+func summarizeTimes(input storedInput, stage string, times []time.Duration) benchResult {
+	sorted := slices.Clone(times)
+	slices.Sort(sorted)
+
+	total := time.Duration(0)
+	for _, t := range sorted {
+		total += t
+	}
+
+	return benchResult{
+		Day:    input.day,
+		Input:  input.path,
+		Stage:  stage,
+		Runs:   len(sorted),
+		Min:    sorted[0],
+		Median: sorted[len(sorted)/2],
+		Mean:   total / time.Duration(len(sorted)),
+	}
+}
+
+func printBenchTable(out io.Writer, results []benchResult) {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "DAY\tINPUT\tSTAGE\tRUNS\tMIN\tMEDIAN\tMEAN\t")
+	for _, result := range results {
+		fmt.Fprintf(
+			writer,
+			"%d\t%s\t%s\t%d\t%s\t%s\t%s\t\n",
+			result.Day,
+			result.Input,
+			result.Stage,
+			result.Runs,
+			roundDuration(result.Min),
+			roundDuration(result.Median),
+			roundDuration(result.Mean),
+		)
+	}
+
+	writer.Flush()
+}
+
+// roundDuration rounds the duration to about four significant figures, so that the table is easier to read
+func roundDuration(d time.Duration) time.Duration {
+	unit := time.Duration(1)
+	for d/unit >= 10000 {
+		unit *= 10
+	}
+
+	return d.Round(unit)
+}
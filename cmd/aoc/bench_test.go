@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+)
+
+// BenchmarkGoldenCases times parsing and each part of every day against its examples. Each case gets its own
+// sub-benchmark (e.g. BenchmarkGoldenCases/day17/example/part2), so a single day can be picked out with -bench.
+func BenchmarkGoldenCases(b *testing.B) {
+	cases, err := findGoldenCases(filepath.Join("..", ".."))
+	if err != nil {
+		b.Fatalf("Failed to find test data: %s", err)
+	}
+
+	for _, goldenCase := range cases {
+		b.Run(fmt.Sprintf("day%d/%s", goldenCase.day, goldenCase.name), func(b *testing.B) {
+			benchmarkInput(b, goldenCase.day, goldenCase.inputPath, goldenCase.flags)
+		})
+	}
+}
+
+// BenchmarkStoredInputs is like BenchmarkGoldenCases, but for the inputs in the inputs directory that verify uses. It
+// is skipped if there are none.
+func BenchmarkStoredInputs(b *testing.B) {
+	inputs, err := findStoredInputs(filepath.Join("..", "..", "inputs"))
+	if errors.Is(err, os.ErrNotExist) {
+		b.Skip("No stored inputs")
+	} else if err != nil {
+		b.Fatalf("Failed to find inputs: %s", err)
+	}
+
+	for _, input := range inputs {
+		b.Run(fmt.Sprintf("day%d/%s", input.day, filepath.Base(input.path)), func(b *testing.B) {
+			benchmarkInput(b, input.day, input.path, []string{})
+		})
+	}
+}
+
+func benchmarkInput(b *testing.B, day int, inputPath string, flags []string) {
+	solver, err := aoc.Lookup(day)
+	if err != nil {
+		b.Fatalf("Failed to look up day: %s", err)
+	}
+
+	dayFlagSet := flag.NewFlagSet(fmt.Sprintf("day %d", day), flag.ContinueOnError)
+	solver.RegisterFlags(dayFlagSet)
+	if err := dayFlagSet.Parse(flags); err != nil {
+		b.Fatalf("Failed to parse flags %v: %s", flags, err)
+	}
+
+	input, err := aoc.ReadInput(inputPath)
+	if err != nil {
+		b.Fatalf("Failed to read input: %s", err)
+	}
+
+	b.Run("parse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := solver.Parse(input); err != nil {
+				b.Fatalf("Failed to parse input: %s", err)
+			}
+		}
+	})
+
+	for _, part := range runnableParts(solver) {
+		b.Run(fmt.Sprintf("part%d", part), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				// Parts may change the parsed input, so each run needs its own
+				b.StopTimer()
+				parsed, err := solver.Parse(input)
+				if err != nil {
+					b.Fatalf("Failed to parse input: %s", err)
+				}
+
+				b.StartTimer()
+				if _, err := solver.RunPart(context.Background(), part, parsed); err != nil {
+					b.Fatalf("Part %d failed: %s", part, err)
+				}
+			}
+		})
+	}
+}
+
+func TestBenchTimesEveryStage(t *testing.T) {
+	inputsDir := filepath.Join(t.TempDir(), "inputs")
+	if err := os.Mkdir(inputsDir, 0o755); err != nil {
+		t.Fatalf("Failed to make inputs directory: %s", err)
+	}
+
+	input, err := os.ReadFile(filepath.Join("..", "..", "day6", "testdata", "example.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read input: %s", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(inputsDir, "day6.txt"), input, 0o644); err != nil {
+		t.Fatalf("Failed to write input: %s", err)
+	}
+
+	out := bytes.Buffer{}
+	if err := benchCommand([]string{"--inputs", inputsDir, "--count", "3", "--format", "json"}, &out); err != nil {
+		t.Fatalf("Failed to benchmark: %s", err)
+	}
+
+	report := benchReport{}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to decode report: %s", err)
+	}
+
+	stages := []string{}
+	for _, result := range report.Results {
+		stages = append(stages, result.Stage)
+		if result.Day != 6 || result.Runs != 3 {
+			t.Errorf("Got day %d with %d runs for %s, not day 6 with 3 runs", result.Day, result.Runs, result.Stage)
+		} else if result.Min > result.Median || result.Min > result.Mean {
+			t.Errorf("Got inconsistent timings for %s: %+v", result.Stage, result)
+		}
+	}
+
+	expectedStages := []string{"parse", "part1", "part2"}
+	if !slices.Equal(stages, expectedStages) {
+		t.Fatalf("Got stages %v, not %v", stages, expectedStages)
+	}
+}
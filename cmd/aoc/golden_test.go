@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+)
+
+// goldenCase is an input from a day's testdata directory, along with the answers it should give. Each input (e.g.
+// example.txt) has its answers in a file with the same name (e.g. example.answers), which looks like
+//
+//	# Comments and blank lines are ignored
+//	flags: --part1steps 6
+//	part1: 16
+//	part2: 50
+//
+// Parts without an answer are not run, and the flags (if any) are given to the day as if they were on the command
+// line.
+type goldenCase struct {
+	day       int
+	name      string
+	inputPath string
+	flags     []string
+	answers   map[int]string
+}
+
+var errMalformedAnswers = errors.New("malformed answers file")
+
+func TestGoldenAnswers(t *testing.T) {
+	cases, err := findGoldenCases(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("Failed to find test data: %s", err)
+	}
+
+	daysWithCases := map[int]struct{}{}
+	for _, goldenCase := range cases {
+		daysWithCases[goldenCase.day] = struct{}{}
+		t.Run(fmt.Sprintf("day%d/%s", goldenCase.day, goldenCase.name), func(t *testing.T) {
+			runGoldenCase(t, goldenCase)
+		})
+	}
+
+	for _, day := range aoc.Days() {
+		if _, ok := daysWithCases[day]; !ok {
+			t.Errorf("Day %d has no test data", day)
+		}
+	}
+}
+
+func runGoldenCase(t *testing.T, goldenCase goldenCase) {
+	solver, err := aoc.Lookup(goldenCase.day)
+	if err != nil {
+		t.Fatalf("Failed to look up day: %s", err)
+	}
+
+	// Registering the flags resets them, so no options will leak between cases
+	dayFlagSet := flag.NewFlagSet(fmt.Sprintf("day %d", goldenCase.day), flag.ContinueOnError)
+	solver.RegisterFlags(dayFlagSet)
+	if err := dayFlagSet.Parse(goldenCase.flags); err != nil {
+		t.Fatalf("Failed to parse flags %v: %s", goldenCase.flags, err)
+	}
+
+	parsed, err := readAndParse(solver, goldenCase.inputPath)
+	if err != nil {
+		t.Fatalf("Failed to parse input: %s", err)
+	}
+
+	for _, part := range []int{1, 2} {
+		expected, ok := goldenCase.answers[part]
+		if !ok {
+			continue
+		}
+
+		answer, err := solver.RunPart(context.Background(), part, parsed)
+		if err != nil {
+			t.Errorf("Part %d failed: %s", part, err)
+		} else if fmt.Sprint(answer) != expected {
+			t.Errorf("Got %v for part %d, not %s", answer, part, expected)
+		}
+	}
+}
+
+// findGoldenCases finds every case in the testdata directory of each day under the given root
+func findGoldenCases(root string) ([]goldenCase, error) {
+	answersPaths, err := filepath.Glob(filepath.Join(root, "day*", "testdata", "*.answers"))
+	if err != nil {
+		return nil, err
+	}
+
+	cases := make([]goldenCase, 0, len(answersPaths))
+	for _, answersPath := range answersPaths {
+		loaded, err := loadGoldenCase(answersPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", answersPath, err)
+		}
+
+		cases = append(cases, loaded)
+	}
+
+	return cases, nil
+}
+
+func loadGoldenCase(answersPath string) (goldenCase, error) {
+	dayDir := filepath.Base(filepath.Dir(filepath.Dir(answersPath)))
+	day, err := strconv.Atoi(strings.TrimPrefix(dayDir, "day"))
+	if err != nil {
+		return goldenCase{}, fmt.Errorf("directory %q is not a day: %w", dayDir, err)
+	}
+
+	rawAnswers, err := os.ReadFile(answersPath)
+	if err != nil {
+		return goldenCase{}, err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(answersPath), ".answers")
+	loaded := goldenCase{
+		day:       day,
+		name:      name,
+		inputPath: filepath.Join(filepath.Dir(answersPath), name+".txt"),
+		flags:     []string{},
+		answers:   map[int]string{},
+	}
+
+	for i, line := range strings.Split(string(rawAnswers), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			return goldenCase{}, fmt.Errorf("%w: line %d has no key", errMalformedAnswers, i+1)
+		}
+
+		value = strings.TrimSpace(value)
+		switch key {
+		case "flags":
+			loaded.flags = strings.Fields(value)
+		case "part1":
+			loaded.answers[1] = value
+		case "part2":
+			loaded.answers[2] = value
+		default:
+			return goldenCase{}, fmt.Errorf("%w: line %d has unknown key %q", errMalformedAnswers, i+1, key)
+		}
+	}
+
+	return loaded, nil
+}
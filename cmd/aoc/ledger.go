@@ -0,0 +1,86 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ledger records the answers that each day gave for each input, so that they can be checked again after the
+// solutions change. Inputs are identified by a hash of their contents, so that the ledger never holds the inputs
+// themselves.
+type ledger struct {
+	// Answers is keyed by day, then part, then input hash
+	Answers map[int]map[int]map[string]string `json:"answers"`
+}
+
+func newLedger() ledger {
+	return ledger{Answers: map[int]map[int]map[string]string{}}
+}
+
+// loadLedger loads the ledger at the given path. If there is no ledger there, an empty one is returned.
+func loadLedger(path string) (ledger, error) {
+	rawLedger, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return newLedger(), nil
+	} else if err != nil {
+		return ledger{}, fmt.Errorf("read ledger: %w", err)
+	}
+
+	loaded := newLedger()
+	if err := json.Unmarshal(rawLedger, &loaded); err != nil {
+		return ledger{}, fmt.Errorf("decode ledger %s: %w", path, err)
+	}
+
+	if loaded.Answers == nil {
+		loaded.Answers = map[int]map[int]map[string]string{}
+	}
+
+	return loaded, nil
+}
+
+// save writes the ledger to the given path
+func (l ledger) save(path string) error {
+	rawLedger, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode ledger: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(rawLedger, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write ledger: %w", err)
+	}
+
+	return nil
+}
+
+// answer gets the answer recorded for the given part of a day, for the input with the given hash
+func (l ledger) answer(day, part int, inputHash string) (string, bool) {
+	answer, ok := l.Answers[day][part][inputHash]
+
+	return answer, ok
+}
+
+// record records the answer for the given part of a day, for the input with the given hash
+func (l ledger) record(day, part int, inputHash string, answer string) {
+	if l.Answers[day] == nil {
+		l.Answers[day] = map[int]map[string]string{}
+	}
+
+	if l.Answers[day][part] == nil {
+		l.Answers[day][part] = map[string]string{}
+	}
+
+	l.Answers[day][part][inputHash] = answer
+}
+
+// hashInput gets the hash that the ledger uses to identify the input. Surrounding whitespace is ignored, so that the
+// hash doesn't depend on how the input was saved.
+func hashInput(input string) string {
+	hash := sha256.Sum256([]byte(strings.TrimSpace(input)))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	_ "github.com/ollien/advent-of-code-2023/day1"
+	_ "github.com/ollien/advent-of-code-2023/day10"
+	_ "github.com/ollien/advent-of-code-2023/day11"
+	_ "github.com/ollien/advent-of-code-2023/day12"
+	_ "github.com/ollien/advent-of-code-2023/day13"
+	_ "github.com/ollien/advent-of-code-2023/day14"
+	_ "github.com/ollien/advent-of-code-2023/day15"
+	_ "github.com/ollien/advent-of-code-2023/day16"
+	_ "github.com/ollien/advent-of-code-2023/day17"
+	_ "github.com/ollien/advent-of-code-2023/day18"
+	_ "github.com/ollien/advent-of-code-2023/day19"
+	_ "github.com/ollien/advent-of-code-2023/day2"
+	_ "github.com/ollien/advent-of-code-2023/day20"
+	_ "github.com/ollien/advent-of-code-2023/day21"
+	_ "github.com/ollien/advent-of-code-2023/day22"
+	_ "github.com/ollien/advent-of-code-2023/day23"
+	_ "github.com/ollien/advent-of-code-2023/day24"
+	_ "github.com/ollien/advent-of-code-2023/day25"
+	_ "github.com/ollien/advent-of-code-2023/day3"
+	_ "github.com/ollien/advent-of-code-2023/day4"
+	_ "github.com/ollien/advent-of-code-2023/day5"
+	_ "github.com/ollien/advent-of-code-2023/day6"
+	_ "github.com/ollien/advent-of-code-2023/day7"
+	_ "github.com/ollien/advent-of-code-2023/day8"
+	_ "github.com/ollien/advent-of-code-2023/day9"
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCommand(os.Args[2:])
+	case "exec":
+		err = execCommand(os.Args[2:])
+	case "list":
+		err = listCommand()
+	case "verify":
+		err = verifyCommand(os.Args[2:], os.Stdout)
+	case "bench":
+		err = benchCommand(os.Args[2:], os.Stdout)
+	default:
+		printUsage()
+		os.Exit(1)
+	}
+
+	if errors.Is(err, errUsage) {
+		printUsage()
+		os.Exit(1)
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintf(
+		os.Stderr,
+		"Usage: %s run --day N [--part N] [--timeout D] [profile options] inputfile [day options]\n",
+		os.Args[0],
+	)
+	fmt.Fprintf(os.Stderr, "       %s exec --day N [profile options] inputfile command [args]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s list\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s verify [--ledger file] [--inputs dir] [--record] [--timeout D]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s bench [--day N] [--inputs dir] [--count N] [--format table|json]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "\nProfile options: [--cpuprofile file] [--memprofile file] [--trace file]\n")
+	fmt.Fprintf(os.Stderr, "Timeouts (D) are durations, like 30s or 1m30s\n")
+}
+
+// runCommand runs one or both parts of a day against the given input file
+func runCommand(args []string) error {
+	flagSet := flag.NewFlagSet("run", flag.ExitOnError)
+	day := flagSet.Int("day", 0, "the day to run")
+	part := flagSet.Int("part", 0, "the part to run (default: every part the day can run)")
+	timeout := flagSet.Duration("timeout", 0, "give up on a part if it runs for longer than this (default: no limit)")
+	profiling := profileOptions{}
+	profiling.registerFlags(flagSet)
+	flagSet.Parse(args)
+	if flagSet.NArg() < 1 {
+		return errUsage
+	}
+
+	solver, err := aoc.Lookup(*day)
+	if err != nil {
+		return err
+	}
+
+	dayFlagSet := flag.NewFlagSet(fmt.Sprintf("day %d", *day), flag.ExitOnError)
+	solver.RegisterFlags(dayFlagSet)
+	dayFlagSet.Parse(flagSet.Args()[1:])
+
+	parts := []int{*part}
+	if *part == 0 {
+		parts = runnableParts(solver)
+	}
+
+	if len(parts) == 0 {
+		return fmt.Errorf("day %d cannot run any parts on its own, see its commands with %s list", *day, os.Args[0])
+	}
+
+	stopProfiling, err := profiling.start()
+	if err != nil {
+		return err
+	}
+
+	err = runParts(solver, *day, parts, flagSet.Arg(0), *timeout)
+
+	return errors.Join(err, stopProfiling())
+}
+
+// runParts parses the given input file, and runs each of the given parts against it
+func runParts(solver aoc.Solver, day int, parts []int, filename string, timeout time.Duration) error {
+	parsed, err := readAndParse(solver, filename)
+	if err != nil {
+		return err
+	}
+
+	// A failing part should not stop the other from running, so we report every failure once we're done
+	partErrs := []error{}
+	for _, partNum := range parts {
+		answer, err := runPart(solver, partNum, parsed, timeout)
+		if err != nil {
+			partErrs = append(partErrs, fmt.Errorf("day %d part %d: %w", day, partNum, err))
+			continue
+		}
+
+		fmt.Printf("Part %d: %v\n", partNum, answer)
+	}
+
+	return errors.Join(partErrs...)
+}
+
+// execCommand runs one of a day's extra commands against the given input file
+func execCommand(args []string) error {
+	flagSet := flag.NewFlagSet("exec", flag.ExitOnError)
+	day := flagSet.Int("day", 0, "the day whose command should be run")
+	profiling := profileOptions{}
+	profiling.registerFlags(flagSet)
+	flagSet.Parse(args)
+	if flagSet.NArg() < 2 {
+		return errUsage
+	}
+
+	solver, err := aoc.Lookup(*day)
+	if err != nil {
+		return err
+	}
+
+	stopProfiling, err := profiling.start()
+	if err != nil {
+		return err
+	}
+
+	parsed, err := readAndParse(solver, flagSet.Arg(0))
+	if err == nil {
+		err = solver.RunCommand(flagSet.Arg(1), parsed, flagSet.Args()[2:], os.Stdout)
+	}
+
+	return errors.Join(err, stopProfiling())
+}
+
+// listCommand lists every registered day, and the commands each of them provides
+func listCommand() error {
+	for _, day := range aoc.Days() {
+		solver, err := aoc.Lookup(day)
+		if err != nil {
+			// Can't happen, we only look up registered days
+			panic(err)
+		}
+
+		fmt.Printf("Day %d: parts %v", day, runnableParts(solver))
+		if commands := solver.CommandNames(); len(commands) > 0 {
+			fmt.Printf(", commands %v", commands)
+		}
+
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func readAndParse(solver aoc.Solver, filename string) (any, error) {
+	input, err := aoc.ReadInput(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseInput(solver, input, filename)
+}
+
+func parseInput(solver aoc.Solver, input string, filename string) (any, error) {
+	parsed, err := solver.Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("parse input: %w", parse.InFile(err, filename))
+	}
+
+	return parsed, nil
+}
+
+// runPart runs a single part against the parsed input, giving up once the timeout has passed. A timeout of zero means
+// there is no limit.
+func runPart(solver aoc.Solver, part int, parsed any, timeout time.Duration) (any, error) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+
+	defer cancel()
+
+	answer, err := solver.RunPart(ctx, part, parsed)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("gave up after %s: %w", timeout, err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return answer, nil
+}
+
+func runnableParts(solver aoc.Solver) []int {
+	parts := []int{}
+	for _, part := range []int{1, 2} {
+		if solver.HasPart(part) {
+			parts = append(parts, part)
+		}
+	}
+
+	return parts
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// profileOptions holds the paths that profiles should be written to. Any that are empty are not collected.
+type profileOptions struct {
+	cpuProfile string
+	memProfile string
+	trace      string
+}
+
+// registerFlags registers the profiling flags on the given flag set
+func (options *profileOptions) registerFlags(flagSet *flag.FlagSet) {
+	flagSet.StringVar(&options.cpuProfile, "cpuprofile", "", "write a CPU profile to the given file")
+	flagSet.StringVar(&options.memProfile, "memprofile", "", "write a memory profile to the given file")
+	flagSet.StringVar(&options.trace, "trace", "", "write an execution trace to the given file")
+}
+
+// start starts collecting the profiles that were asked for. The returned function stops collecting them, and must be
+// called for them to be written out.
+func (options profileOptions) start() (func() error, error) {
+	stops := []func() error{}
+	stopAll := func() error {
+		errs := []error{}
+		// Stop in the reverse order we started, so that a profile doesn't see the others being stopped
+		for i := len(stops) - 1; i >= 0; i-- {
+			errs = append(errs, stops[i]())
+		}
+
+		return errors.Join(errs...)
+	}
+
+	if options.cpuProfile != "" {
+		stop, err := startCPUProfile(options.cpuProfile)
+		if err != nil {
+			return nil, errors.Join(err, stopAll())
+		}
+
+		stops = append(stops, stop)
+	}
+
+	if options.trace != "" {
+		stop, err := startTrace(options.trace)
+		if err != nil {
+			return nil, errors.Join(err, stopAll())
+		}
+
+		stops = append(stops, stop)
+	}
+
+	if options.memProfile != "" {
+		path := options.memProfile
+		stops = append(stops, func() error {
+			return writeMemProfile(path)
+		})
+	}
+
+	return stopAll, nil
+}
+
+func startCPUProfile(path string) (func() error, error) {
+	profileFile, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create CPU profile: %w", err)
+	}
+
+	if err := pprof.StartCPUProfile(profileFile); err != nil {
+		profileFile.Close()
+
+		return nil, fmt.Errorf("start CPU profile: %w", err)
+	}
+
+	return func() error {
+		pprof.StopCPUProfile()
+		if err := profileFile.Close(); err != nil {
+			return fmt.Errorf("write CPU profile: %w", err)
+		}
+
+		return nil
+	}, nil
+}
+
+func startTrace(path string) (func() error, error) {
+	traceFile, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create trace: %w", err)
+	}
+
+	if err := trace.Start(traceFile); err != nil {
+		traceFile.Close()
+
+		return nil, fmt.Errorf("start trace: %w", err)
+	}
+
+	return func() error {
+		trace.Stop()
+		if err := traceFile.Close(); err != nil {
+			return fmt.Errorf("write trace: %w", err)
+		}
+
+		return nil
+	}, nil
+}
+
+// writeMemProfile writes every allocation made so far to the given file, the same as go test's -memprofile
+func writeMemProfile(path string) error {
+	profileFile, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create memory profile: %w", err)
+	}
+
+	defer profileFile.Close()
+
+	// Make sure the profile is up to date with everything that has been freed
+	runtime.GC()
+	if err := pprof.Lookup("allocs").WriteTo(profileFile, 0); err != nil {
+		return fmt.Errorf("write memory profile: %w", err)
+	}
+
+	return profileFile.Close()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfilesAreWritten(t *testing.T) {
+	dir := t.TempDir()
+	options := profileOptions{
+		cpuProfile: filepath.Join(dir, "cpu.out"),
+		memProfile: filepath.Join(dir, "mem.out"),
+		trace:      filepath.Join(dir, "trace.out"),
+	}
+
+	stop, err := options.start()
+	if err != nil {
+		t.Fatalf("Failed to start profiling: %s", err)
+	}
+
+	if err := stop(); err != nil {
+		t.Fatalf("Failed to stop profiling: %s", err)
+	}
+
+	for _, path := range []string{options.cpuProfile, options.memProfile, options.trace} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Errorf("Failed to stat %s: %s", path, err)
+		} else if info.Size() == 0 {
+			t.Errorf("%s is empty", path)
+		}
+	}
+}
+
+func TestProfilingIsStoppedIfAProfileCannotBeStarted(t *testing.T) {
+	dir := t.TempDir()
+	options := profileOptions{
+		cpuProfile: filepath.Join(dir, "cpu.out"),
+		trace:      filepath.Join(dir, "missing", "trace.out"),
+	}
+
+	if _, err := options.start(); err == nil {
+		t.Fatal("Started profiling, even though the trace cannot be written")
+	}
+
+	// The CPU profile must have been stopped, or else this will fail
+	stop, err := profileOptions{cpuProfile: filepath.Join(dir, "cpu2.out")}.start()
+	if err != nil {
+		t.Fatalf("Failed to start profiling again: %s", err)
+	}
+
+	if err := stop(); err != nil {
+		t.Fatalf("Failed to stop profiling: %s", err)
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+)
+
+var errRegressions = errors.New("answers do not match the ledger")
+
+// storedInput is an input file that verify found, along with the day it is for
+type storedInput struct {
+	day  int
+	path string
+}
+
+// verifyResult is the outcome of checking a single part against an input
+type verifyResult int
+
+const (
+	verifyMatched verifyResult = iota
+	verifyRecorded
+	verifyUnrecorded
+	verifyFailed
+)
+
+// verifyCommand runs every day against every input in a directory, and checks the answers against those in the
+// ledger. With --record, answers for parts that are not in the ledger yet are added to it.
+func verifyCommand(args []string, out io.Writer) error {
+	flagSet := flag.NewFlagSet("verify", flag.ExitOnError)
+	ledgerPath := flagSet.String("ledger", "answers.json", "the ledger of recorded answers")
+	inputsDir := flagSet.String(
+		"inputs",
+		"inputs",
+		"the directory holding the inputs, each of which must have its day in its path (e.g. inputs/alice/day5.txt)",
+	)
+	record := flagSet.Bool("record", false, "record the answers for any parts that are not in the ledger yet")
+	timeout := flagSet.Duration("timeout", 0, "fail a part if it runs for longer than this (default: no limit)")
+	flagSet.Parse(args)
+
+	answers, err := loadLedger(*ledgerPath)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := findStoredInputs(*inputsDir)
+	if err != nil {
+		return err
+	}
+
+	counts := map[verifyResult]int{}
+	for _, input := range inputs {
+		results, err := verifyInput(input, answers, *record, *timeout, out)
+		if err != nil {
+			fmt.Fprintf(out, "FAIL day %d %s: %s\n", input.day, input.path, err)
+			counts[verifyFailed]++
+		}
+
+		for _, result := range results {
+			counts[result]++
+		}
+	}
+
+	if counts[verifyRecorded] > 0 {
+		if err := answers.save(*ledgerPath); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(
+		out,
+		"%d inputs: %d matched, %d recorded, %d unrecorded, %d failed\n",
+		len(inputs),
+		counts[verifyMatched],
+		counts[verifyRecorded],
+		counts[verifyUnrecorded],
+		counts[verifyFailed],
+	)
+
+	if counts[verifyFailed] > 0 {
+		return fmt.Errorf("%w (%d failed)", errRegressions, counts[verifyFailed])
+	}
+
+	return nil
+}
+
+// verifyInput runs each part of the input's day against it, checking (or recording) the answers in the ledger
+func verifyInput(
+	input storedInput,
+	answers ledger,
+	record bool,
+	timeout time.Duration,
+	out io.Writer,
+) ([]verifyResult, error) {
+	solver, err := aoc.Lookup(input.day)
+	if err != nil {
+		return nil, err
+	}
+
+	// The answers in the ledger are only meaningful with the day's default options
+	solver.RegisterFlags(flag.NewFlagSet(fmt.Sprintf("day %d", input.day), flag.ContinueOnError))
+
+	rawInput, err := aoc.ReadInput(input.path)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := parseInput(solver, rawInput, input.path)
+	if err != nil {
+		return nil, err
+	}
+
+	inputHash := hashInput(rawInput)
+	results := []verifyResult{}
+	for _, part := range runnableParts(solver) {
+		describe := func(format string, a ...any) {
+			fmt.Fprintf(out, "day %d part %d %s: %s\n", input.day, part, input.path, fmt.Sprintf(format, a...))
+		}
+
+		recorded, haveRecorded := answers.answer(input.day, part, inputHash)
+		rawAnswer, err := runPart(solver, part, parsed, timeout)
+		if err != nil {
+			describe("FAIL: %s", err)
+			results = append(results, verifyFailed)
+			continue
+		}
+
+		answer := fmt.Sprint(rawAnswer)
+		switch {
+		case haveRecorded && answer == recorded:
+			describe("ok")
+			results = append(results, verifyMatched)
+		case haveRecorded:
+			describe("FAIL: got %s, but %s was recorded", answer, recorded)
+			results = append(results, verifyFailed)
+		case record:
+			answers.record(input.day, part, inputHash, answer)
+			describe("recorded %s", answer)
+			results = append(results, verifyRecorded)
+		default:
+			describe("not in the ledger (got %s)", answer)
+			results = append(results, verifyUnrecorded)
+		}
+	}
+
+	return results, nil
+}
+
+// findStoredInputs finds every input under the given directory, sorted by day. The day is taken from the last part
+// of each file's path that names one (e.g. alice/day5.txt or day5/alice.txt).
+func findStoredInputs(dir string) ([]storedInput, error) {
+	dayPattern := regexp.MustCompile(`day(\d+)`)
+	inputs := []storedInput{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		dayMatches := dayPattern.FindAllStringSubmatch(relPath, -1)
+		if len(dayMatches) == 0 {
+			return fmt.Errorf("cannot tell which day %s is for", path)
+		}
+
+		day, err := strconv.Atoi(dayMatches[len(dayMatches)-1][1])
+		if err != nil {
+			return fmt.Errorf("day in %s: %w", path, err)
+		}
+
+		inputs = append(inputs, storedInput{day: day, path: path})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find inputs: %w", err)
+	}
+
+	slices.SortStableFunc(inputs, func(a, b storedInput) int {
+		if a.day != b.day {
+			return a.day - b.day
+		}
+
+		return strings.Compare(a.path, b.path)
+	})
+
+	return inputs, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyFindsRegressionsInRecordedAnswers(t *testing.T) {
+	dir := t.TempDir()
+	ledgerPath := filepath.Join(dir, "answers.json")
+	inputsDir := filepath.Join(dir, "inputs")
+	if err := os.MkdirAll(filepath.Join(inputsDir, "alice"), 0o755); err != nil {
+		t.Fatalf("Failed to make inputs directory: %s", err)
+	}
+
+	input, err := os.ReadFile(filepath.Join("..", "..", "day6", "testdata", "example.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read input: %s", err)
+	}
+
+	inputPath := filepath.Join(inputsDir, "alice", "day6.txt")
+	if err := os.WriteFile(inputPath, input, 0o644); err != nil {
+		t.Fatalf("Failed to write input: %s", err)
+	}
+
+	args := []string{"--ledger", ledgerPath, "--inputs", inputsDir}
+	if err := verifyCommand(append(args, "--record"), io.Discard); err != nil {
+		t.Fatalf("Failed to record answers: %s", err)
+	}
+
+	recorded, err := loadLedger(ledgerPath)
+	if err != nil {
+		t.Fatalf("Failed to load ledger: %s", err)
+	}
+
+	inputHash := hashInput(string(input))
+	if answer, ok := recorded.answer(6, 2, inputHash); !ok || answer != "71503" {
+		t.Fatalf("Got (%q, %t) for part 2, not (\"71503\", true)", answer, ok)
+	}
+
+	if err := verifyCommand(args, io.Discard); err != nil {
+		t.Fatalf("Answers did not match what was recorded: %s", err)
+	}
+
+	recorded.record(6, 2, inputHash, "71504")
+	if err := recorded.save(ledgerPath); err != nil {
+		t.Fatalf("Failed to save ledger: %s", err)
+	}
+
+	if err := verifyCommand(args, io.Discard); !errors.Is(err, errRegressions) {
+		t.Fatalf("Got error %v, not %v", err, errRegressions)
+	}
+}
@@ -1,55 +1,37 @@
-package main
+package day1
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
-)
-
-func main() {
-	if len(os.Args) != 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s inputfile\n", os.Args[0])
-		os.Exit(1)
-	}
-
-	filename := os.Args[1]
-	inputFile, err := os.Open(filename)
-	if err != nil {
-		panic(fmt.Sprintf("failed to open input file: %s", err))
-	}
-
-	defer inputFile.Close()
 
-	inputFileBytes, err := io.ReadAll(inputFile)
-	if err != nil {
-		panic(fmt.Sprintf("failed to read input file: %s", err))
-	}
+	"github.com/ollien/advent-of-code-2023/aoc"
+)
 
-	input := string(inputFileBytes)
-	inputLines := strings.Split(strings.TrimSpace(input), "\n")
+var ErrNoCalibrationValue = errors.New("no digits in calibration value")
 
-	fmt.Printf("Part 1: %d\n", part1(inputLines))
-	fmt.Printf("Part 2: %d\n", part2(inputLines))
+func init() {
+	aoc.Register(1, aoc.Solution[[]string, int]{
+		Parse: func(input string) ([]string, error) {
+			return strings.Split(input, "\n"), nil
+		},
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func part1(input []string) int {
+func part1(_ context.Context, input []string) (int, error) {
 	digits := []string{
 		"0", "1", "2", "3", "4", "5", "6", "7", "8", "9",
 	}
 
-	result, err := solve(input, digits, strconv.Atoi)
-	if err != nil {
-		panic(err)
-	}
-
-	return result
+	return solve(input, digits, strconv.Atoi)
 }
 
-func part2(input []string) int {
+func part2(_ context.Context, input []string) (int, error) {
 	digits := []string{
 		"0", "1", "2", "3", "4", "5", "6", "7", "8", "9",
 	}
@@ -62,7 +44,7 @@ func part2(input []string) int {
 	copy(allPossible, digits)
 	allPossible = append(allPossible, words...)
 
-	result, err := solve(input, allPossible, func(s string) (int, error) {
+	return solve(input, allPossible, func(s string) (int, error) {
 		n, err := strconv.Atoi(s)
 		if err == nil {
 			return n, nil
@@ -75,12 +57,6 @@ func part2(input []string) int {
 
 		return 0, errors.New("invalid digit")
 	})
-
-	if err != nil {
-		panic(err)
-	}
-
-	return result
 }
 
 // filterToMatches find all overlapping matches of the stringset "valid" in "line"
@@ -142,7 +118,7 @@ func mapToDigits(items []string, toDigit func(string) (int, error)) ([]int, erro
 func getCoordinate(line string, validNumbers []string, convertToNumber func(string) (int, error)) (int, error) {
 	candidates := filterToMatches(line, validNumbers)
 	if len(candidates) == 0 {
-		return 0, errors.New("invalid calibration value")
+		return 0, ErrNoCalibrationValue
 	}
 
 	digits, err := mapToDigits(candidates, convertToNumber)
@@ -165,7 +141,7 @@ func solve(input []string, validNumbers []string, convert func(string) (int, err
 	for _, line := range input {
 		coordinate, err := getCoordinate(line, validNumbers, convert)
 		if err != nil {
-			return 0, fmt.Errorf("no valid coordinate on line '%s': %s", line, err)
+			return 0, fmt.Errorf("no valid coordinate on line '%s': %w", line, err)
 		}
 
 		total += coordinate
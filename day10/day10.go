@@ -1,21 +1,16 @@
 // I definitely overcomplicated this problem, but it took me a very long time to visualize things properly
 
-package main
+package day10
 
 import (
-	"cmp"
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"os"
-	"slices"
 	"strings"
-)
 
-type Coordinate struct {
-	row int
-	col int
-}
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/grid"
+)
 
 type Pipe int
 
@@ -36,47 +31,14 @@ const (
 	ScanDirectionVertical
 )
 
-type PipeMap map[Coordinate]Pipe
-
-var ErrMissingPipe = errors.New("no pipe at location")
-
-func (coordinate Coordinate) North() Coordinate {
-	return Coordinate{row: coordinate.row - 1, col: coordinate.col}
-}
-
-func (coordinate Coordinate) South() Coordinate {
-	return Coordinate{row: coordinate.row + 1, col: coordinate.col}
-}
+type PipeMap map[grid.Coordinate]Pipe
 
-func (coordinate Coordinate) East() Coordinate {
-	return Coordinate{row: coordinate.row, col: coordinate.col + 1}
+type PipeLayout struct {
+	pipeMap       PipeMap
+	startPosition grid.Coordinate
 }
 
-func (coordinate Coordinate) West() Coordinate {
-	return Coordinate{row: coordinate.row, col: coordinate.col - 1}
-}
-
-// CardinalNeighbors gets all the neighbors of the given coordinate (cardinal directions)
-func (coordinate Coordinate) CardinalNeighbors() []Coordinate {
-	return []Coordinate{
-		coordinate.North(),
-		coordinate.South(),
-		coordinate.East(),
-		coordinate.West(),
-	}
-}
-
-// AllNeighbors gets all the neighbors of the given coordinate in all directions
-func (coordinate Coordinate) AllNeighbors() []Coordinate {
-	diagonalNeighbors := []Coordinate{
-		{row: coordinate.row - 1, col: coordinate.col - 1},
-		{row: coordinate.row - 1, col: coordinate.col + 1},
-		{row: coordinate.row + 1, col: coordinate.col - 1},
-		{row: coordinate.row + 1, col: coordinate.col + 1},
-	}
-
-	return append(coordinate.CardinalNeighbors(), diagonalNeighbors...)
-}
+var ErrMissingPipe = errors.New("no pipe at location")
 
 // ConnectsNorth will determine if the given pipe can connect to a pipe to its north
 func (pipe Pipe) ConnectsNorth() bool {
@@ -118,13 +80,13 @@ func (pipe Pipe) IsParallelToScan(scanDir ScanDirection) bool {
 }
 
 // ConnectedNeighbors gets only the connected neighbors to a pipe at a position
-func (pipeMap PipeMap) ConnectedNeighbors(position Coordinate) []Coordinate {
+func (pipeMap PipeMap) ConnectedNeighbors(position grid.Coordinate) []grid.Coordinate {
 	_, ok := pipeMap[position]
 	if !ok {
-		return []Coordinate{}
+		return []grid.Coordinate{}
 	}
 
-	connectedNeighbors := []Coordinate{}
+	connectedNeighbors := []grid.Coordinate{}
 	for _, neighbor := range position.CardinalNeighbors() {
 		if pipeMap.PipesConnect(position, neighbor) {
 			connectedNeighbors = append(connectedNeighbors, neighbor)
@@ -135,29 +97,16 @@ func (pipeMap PipeMap) ConnectedNeighbors(position Coordinate) []Coordinate {
 }
 
 // PipeBounds finds the bounds of the pieps on the map
-func (pipeMap PipeMap) PipeBounds() (Coordinate, Coordinate) {
-	positions := mapKeys(pipeMap)
-	compareRow := func(a, b Coordinate) int {
-		return cmp.Compare(a.row, b.row)
-	}
-
-	compareCol := func(a, b Coordinate) int {
-		return cmp.Compare(a.col, b.col)
-	}
-	minRow := slices.MinFunc(positions, compareRow).row
-	maxRow := slices.MaxFunc(positions, compareRow).row
-	minCol := slices.MinFunc(positions, compareCol).col
-	maxCol := slices.MaxFunc(positions, compareCol).col
-
-	return Coordinate{row: minRow, col: minCol}, Coordinate{row: maxRow, col: maxCol}
+func (pipeMap PipeMap) PipeBounds() (grid.Coordinate, grid.Coordinate) {
+	return grid.Bounds(mapKeys(pipeMap))
 }
 
 // Print will print the entire map in the form the puzzle presents it
 func (pipeMap PipeMap) Print() {
 	minCorner, maxCorner := pipeMap.PipeBounds()
-	for row := minCorner.row; row <= maxCorner.row; row++ {
-		for col := minCorner.col; col <= maxCorner.col; col++ {
-			location := Coordinate{row: row, col: col}
+	for row := minCorner.Row; row <= maxCorner.Row; row++ {
+		for col := minCorner.Col; col <= maxCorner.Col; col++ {
+			location := grid.Coordinate{Row: row, Col: col}
 			pipe, ok := pipeMap[location]
 			if !ok {
 				fmt.Print(".")
@@ -180,7 +129,7 @@ func (pipeMap PipeMap) Print() {
 }
 
 // PipesConnect will check if the pipes at the given positions connect
-func (pipeMap PipeMap) PipesConnect(position1, position2 Coordinate) bool {
+func (pipeMap PipeMap) PipesConnect(position1, position2 grid.Coordinate) bool {
 	pipe1 := pipeMap[position1]
 	pipe2 := pipeMap[position2]
 
@@ -197,43 +146,32 @@ func (pipeMap PipeMap) PipesConnect(position1, position2 Coordinate) bool {
 	}
 }
 
-func main() {
-	if len(os.Args) != 2 && len(os.Args) != 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s inputfile\n", os.Args[0])
-		os.Exit(1)
-	}
-
-	inputFilename := os.Args[1]
-	inputFile, err := os.Open(inputFilename)
-	if err != nil {
-		panic(fmt.Sprintf("could not open input file: %s", err))
-	}
-
-	defer inputFile.Close()
-
-	inputBytes, err := io.ReadAll(inputFile)
-	if err != nil {
-		panic(fmt.Sprintf("could not read input file: %s", err))
-	}
-
-	input := strings.TrimSpace(string(inputBytes))
-	inputLines := strings.Split(input, "\n")
-	pipeMap, startPosition, err := parsePipeMap(inputLines)
-	if err != nil {
-		panic(fmt.Sprintf("could not parse pipes: %s", err))
-	}
+func init() {
+	aoc.Register(10, aoc.Solution[PipeLayout, int]{
+		Parse: func(input string) (PipeLayout, error) {
+			pipeMap, startPosition, err := parsePipeMap(strings.Split(input, "\n"))
+			if err != nil {
+				return PipeLayout{}, err
+			}
 
-	fmt.Printf("Part 1: %d\n", part1(pipeMap, startPosition))
-	fmt.Printf("Part 2: %d\n", part2(pipeMap, startPosition))
+			return PipeLayout{pipeMap: pipeMap, startPosition: startPosition}, nil
+		},
+		Part1: func(_ context.Context, layout PipeLayout) (int, error) {
+			return part1(layout.pipeMap, layout.startPosition)
+		},
+		Part2: func(_ context.Context, layout PipeLayout) (int, error) {
+			return part2(layout.pipeMap, layout.startPosition)
+		},
+	})
 }
 
-func part1(pipeMap PipeMap, startPosition Coordinate) int {
+func part1(pipeMap PipeMap, startPosition grid.Coordinate) (int, error) {
 	type Visit struct {
-		position Coordinate
+		position grid.Coordinate
 		distance int
 	}
 	maxDistance := 0
-	visited := map[Coordinate]struct{}{}
+	visited := map[grid.Coordinate]struct{}{}
 	toVisit := []Visit{{position: startPosition, distance: 0}}
 	for len(toVisit) > 0 {
 		visiting := toVisit[0]
@@ -258,10 +196,10 @@ func part1(pipeMap PipeMap, startPosition Coordinate) int {
 		}
 	}
 
-	return maxDistance
+	return maxDistance, nil
 }
 
-func part2(pipeMap PipeMap, startPosition Coordinate) int {
+func part2(pipeMap PipeMap, startPosition grid.Coordinate) (int, error) {
 	mainLoopMap := traceMainLoop(pipeMap, startPosition)
 
 	regions := findEmptyRegions(mainLoopMap, startPosition)
@@ -273,13 +211,13 @@ func part2(pipeMap PipeMap, startPosition Coordinate) int {
 		}
 	}
 
-	return area
+	return area, nil
 }
 
 // traceMainLoop walks the pipes and finds the pipes relevant to the problem
-func traceMainLoop(pipeMap PipeMap, startPosition Coordinate) PipeMap {
-	visited := map[Coordinate]Pipe{}
-	toVisit := []Coordinate{startPosition}
+func traceMainLoop(pipeMap PipeMap, startPosition grid.Coordinate) PipeMap {
+	visited := map[grid.Coordinate]Pipe{}
+	toVisit := []grid.Coordinate{startPosition}
 	for len(toVisit) > 0 {
 		visitingPosition := toVisit[0]
 		toVisit = toVisit[1:]
@@ -299,7 +237,7 @@ func traceMainLoop(pipeMap PipeMap, startPosition Coordinate) PipeMap {
 }
 
 // isRegionExternallyAccessible indicates whether or not all of the given coordinates are internal to the loop
-func isRegionExternallyAccessible(pipeMap PipeMap, region []Coordinate) bool {
+func isRegionExternallyAccessible(pipeMap PipeMap, region []grid.Coordinate) bool {
 	for _, pos := range region {
 		if !isInsideViaRay(pipeMap, pos, ScanDirectionHorizontal) || !isInsideViaRay(pipeMap, pos, ScanDirectionVertical) {
 			return true
@@ -311,12 +249,12 @@ func isRegionExternallyAccessible(pipeMap PipeMap, region []Coordinate) bool {
 
 // isInsideViaRay casts a ray in the given direction, counting the number of edge crossings to determine
 // if a tile is inside
-func isInsideViaRay(pipeMap PipeMap, target Coordinate, direction ScanDirection) bool {
+func isInsideViaRay(pipeMap PipeMap, target grid.Coordinate, direction ScanDirection) bool {
 	cursor := target
 	if direction == ScanDirectionHorizontal {
-		cursor.col = 0
+		cursor.Col = 0
 	} else {
-		cursor.row = 0
+		cursor.Row = 0
 	}
 
 	pipeBuffer := []Pipe{}
@@ -327,9 +265,9 @@ func isInsideViaRay(pipeMap PipeMap, target Coordinate, direction ScanDirection)
 		}
 
 		if direction == ScanDirectionHorizontal {
-			cursor.col++
+			cursor.Col++
 		} else {
-			cursor.row++
+			cursor.Row++
 		}
 	}
 	crossings += numRayCrossings(direction, pipeBuffer)
@@ -358,10 +296,10 @@ func numRayCrossings(scanDirection ScanDirection, scannedPipes []Pipe) int {
 }
 
 // findEmptyRegions finds all of the locations where there are empty positions on the graph
-func findEmptyRegions(pipeMap PipeMap, startPosition Coordinate) [][]Coordinate {
+func findEmptyRegions(pipeMap PipeMap, startPosition grid.Coordinate) [][]grid.Coordinate {
 	emptyPositions := findEmptyPositions(pipeMap)
-	regions := [][]Coordinate{}
-	visited := map[Coordinate]struct{}{}
+	regions := [][]grid.Coordinate{}
+	visited := map[grid.Coordinate]struct{}{}
 	for _, position := range emptyPositions {
 		if _, ok := visited[position]; ok {
 			continue
@@ -378,12 +316,12 @@ func findEmptyRegions(pipeMap PipeMap, startPosition Coordinate) [][]Coordinate
 }
 
 // findEmptyPositions finds all empty positions on the graph
-func findEmptyPositions(pipeMap PipeMap) []Coordinate {
+func findEmptyPositions(pipeMap PipeMap) []grid.Coordinate {
 	minCorner, maxCorner := pipeMap.PipeBounds()
-	empty := []Coordinate{}
-	for row := minCorner.row; row < maxCorner.row; row++ {
-		for col := minCorner.col; col < maxCorner.col; col++ {
-			pos := Coordinate{row: row, col: col}
+	empty := []grid.Coordinate{}
+	for row := minCorner.Row; row < maxCorner.Row; row++ {
+		for col := minCorner.Col; col < maxCorner.Col; col++ {
+			pos := grid.Coordinate{Row: row, Col: col}
 			if pipeMap[pos] == PipeUnknown {
 				empty = append(empty, pos)
 			}
@@ -394,17 +332,17 @@ func findEmptyPositions(pipeMap PipeMap) []Coordinate {
 }
 
 // flood performs a flood fill to locate neighboring empty spots
-func flood(pipeMap PipeMap, start Coordinate) []Coordinate {
+func flood(pipeMap PipeMap, start grid.Coordinate) []grid.Coordinate {
 	minCorner, maxCorner := pipeMap.PipeBounds()
-	toVisit := []Coordinate{start}
-	visited := map[Coordinate]struct{}{}
+	toVisit := []grid.Coordinate{start}
+	visited := map[grid.Coordinate]struct{}{}
 	for len(toVisit) > 0 {
 		visiting := toVisit[0]
 		toVisit = toVisit[1:]
 		if _, ok := pipeMap[visiting]; ok {
 			// If we've hit a pipe on the bounding box, don't keep filling
 			continue
-		} else if visiting.row < minCorner.row || visiting.col < minCorner.col || visiting.row > maxCorner.row || visiting.col > maxCorner.col {
+		} else if visiting.Row < minCorner.Row || visiting.Col < minCorner.Col || visiting.Row > maxCorner.Row || visiting.Col > maxCorner.Col {
 			// if we've moved out of bounds, don't continue either
 			continue
 		} else if _, ok := visited[visiting]; ok {
@@ -419,19 +357,19 @@ func flood(pipeMap PipeMap, start Coordinate) []Coordinate {
 	return mapKeys(visited)
 }
 
-func parsePipeMap(inputLines []string) (PipeMap, Coordinate, error) {
+func parsePipeMap(inputLines []string) (PipeMap, grid.Coordinate, error) {
 	pipeMap := PipeMap{}
-	startPosition := (*Coordinate)(nil)
+	startPosition := (*grid.Coordinate)(nil)
 	for row, line := range inputLines {
 		for col, char := range line {
 			pipe, err := parsePipeChar(char)
 			if errors.Is(err, ErrMissingPipe) {
 				continue
 			} else if err != nil {
-				return nil, Coordinate{}, fmt.Errorf("malformed at (%d, %d): %w", row, col, err)
+				return nil, grid.Coordinate{}, fmt.Errorf("malformed at (%d, %d): %w", row, col, err)
 			}
 
-			position := Coordinate{row: row, col: col}
+			position := grid.Coordinate{Row: row, Col: col}
 			pipeMap[position] = pipe
 			if pipe == PipeUnknown {
 				startPosition = &position
@@ -440,12 +378,12 @@ func parsePipeMap(inputLines []string) (PipeMap, Coordinate, error) {
 	}
 
 	if startPosition == nil {
-		return nil, Coordinate{}, errors.New("no start position found")
+		return nil, grid.Coordinate{}, errors.New("no start position found")
 	}
 
 	startPipeType, err := inferPipeType(pipeMap, *startPosition)
 	if err != nil {
-		return nil, Coordinate{}, fmt.Errorf("infer start pipe type: %w", err)
+		return nil, grid.Coordinate{}, fmt.Errorf("infer start pipe type: %w", err)
 	}
 
 	pipeMap[*startPosition] = startPipeType
@@ -477,11 +415,11 @@ func parsePipeChar(c rune) (Pipe, error) {
 }
 
 // inferPipeType will use the PipeMap to infer what type of Pipe should exist at the given location
-func inferPipeType(pipeMap PipeMap, position Coordinate) (Pipe, error) {
-	north := pipeMap[Coordinate{row: position.row - 1, col: position.col}]
-	south := pipeMap[Coordinate{row: position.row + 1, col: position.col}]
-	west := pipeMap[Coordinate{row: position.row, col: position.col - 1}]
-	east := pipeMap[Coordinate{row: position.row, col: position.col + 1}]
+func inferPipeType(pipeMap PipeMap, position grid.Coordinate) (Pipe, error) {
+	north := pipeMap[position.North()]
+	south := pipeMap[position.South()]
+	west := pipeMap[position.West()]
+	east := pipeMap[position.East()]
 
 	if north.ConnectsSouth() && south.ConnectsNorth() {
 		return PipeVertical, nil
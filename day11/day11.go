@@ -0,0 +1,142 @@
+package day11
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/grid"
+)
+
+func init() {
+	aoc.Register(11, aoc.Solution[[]grid.Coordinate, int]{
+		Parse: func(input string) ([]grid.Coordinate, error) {
+			return parseInputMatrix(strings.Split(input, "\n"))
+		},
+		Part1: part1,
+		Part2: part2,
+	})
+}
+
+func part1(_ context.Context, nodes []grid.Coordinate) (int, error) {
+	expanded := expandUniverse(nodes, 2)
+	return computePairwiseDistanceTotal(expanded), nil
+}
+
+func part2(_ context.Context, nodes []grid.Coordinate) (int, error) {
+	expanded := expandUniverse(nodes, 1_000_000)
+	return computePairwiseDistanceTotal(expanded), nil
+}
+
+func computePairwiseDistanceTotal(nodes []grid.Coordinate) int {
+	type Pair struct {
+		node1 grid.Coordinate
+		node2 grid.Coordinate
+	}
+
+	total := 0
+	for i := 0; i < len(nodes)-1; i++ {
+		for j := i + 1; j < len(nodes); j++ {
+			pair := Pair{node1: nodes[i], node2: nodes[j]}
+			distance := pair.node1.ManhattanDistance(pair.node2)
+
+			total += distance
+		}
+	}
+
+	return total
+}
+
+func expandUniverse(nodes []grid.Coordinate, expansion int) []grid.Coordinate {
+	rowExpanded := expandAlongAxis(
+		nodes,
+		expansion,
+		func(c grid.Coordinate) int { return c.Row },
+		func(c grid.Coordinate, n int) grid.Coordinate { return grid.Coordinate{Row: n, Col: c.Col} },
+	)
+
+	expanded := expandAlongAxis(
+		rowExpanded,
+		expansion,
+		func(c grid.Coordinate) int { return c.Col },
+		func(c grid.Coordinate, n int) grid.Coordinate { return grid.Coordinate{Row: c.Row, Col: n} },
+	)
+
+	return expanded
+}
+
+// expandAlongAxis will expand by the given amount the universe only along a single axis.
+// getAxis will allow the function to get the value of a single axis, given a coordinate,
+// and setAxis must return a new coordinate with the same axis set to the given value.
+func expandAlongAxis(
+	nodes []grid.Coordinate,
+	expansion int,
+	getAxis func(grid.Coordinate) int,
+	setAxisValue func(grid.Coordinate, int) grid.Coordinate,
+) []grid.Coordinate {
+	expanded := slices.Clone(nodes)
+	slices.SortFunc(expanded, func(a, b grid.Coordinate) int { return cmp.Compare(getAxis(a), getAxis(b)) })
+
+	blank := findBlankAxisValues(nodes, getAxis)
+	totalExpansion := 0
+	for _, blankIdx := range blank {
+		for i := range expanded {
+			axisValue := getAxis(expanded[i])
+			if axisValue <= blankIdx+totalExpansion {
+				continue
+			}
+
+			expanded[i] = setAxisValue(expanded[i], axisValue+(expansion-1))
+		}
+		totalExpansion += (expansion - 1)
+	}
+
+	return expanded
+}
+
+// findBlankAxisValues finds all the positions where all values along the given axis are blank
+func findBlankAxisValues(nodes []grid.Coordinate, getAxis func(grid.Coordinate) int) []int {
+	maxCoord := slices.MaxFunc(nodes, func(node1, node2 grid.Coordinate) int {
+		return cmp.Compare(getAxis(node1), getAxis(node2))
+	})
+
+	axisMax := getAxis(maxCoord)
+	knownAlongAxis := mapToSet(nodes, getAxis)
+	blank := []int{}
+	for i := 0; i <= axisMax; i++ {
+		if _, ok := knownAlongAxis[i]; !ok {
+			blank = append(blank, i)
+		}
+	}
+
+	return blank
+}
+
+// mapToSet maps all values of the given input and turns them into a set
+func mapToSet[T any, U comparable, S ~[]T](items S, mapper func(T) U) map[U]struct{} {
+	res := map[U]struct{}{}
+	for _, item := range items {
+		key := mapper(item)
+		res[key] = struct{}{}
+	}
+
+	return res
+}
+
+func parseInputMatrix(input []string) ([]grid.Coordinate, error) {
+	universe, err := grid.Parse(input, func(char rune) (rune, error) {
+		if char != '#' && char != '.' {
+			return 0, fmt.Errorf("invalid input char %c", char)
+		}
+
+		return char, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return universe.FindAll(func(char rune) bool { return char == '#' }), nil
+}
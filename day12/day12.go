@@ -1,15 +1,14 @@
-package main
+package day12
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"io"
-	"os"
 	"regexp"
 	"slices"
-	"strconv"
-	"strings"
 	"sync"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
 )
 
 type SpringState int
@@ -58,12 +57,27 @@ func (states SpringStates) String() string {
 	return res
 }
 
-func (r Record) CountPossibleStates() int {
-	return r.generateStates(r.states, 0, 0, make(map[memoKey]int))
+// CountPossibleStates counts the ways the unknown springs could be filled in to match the sequences. If the context
+// is done before they are counted, the context's error is returned.
+func (r Record) CountPossibleStates(ctx context.Context) (int, error) {
+	count := r.generateStates(ctx, r.states, 0, 0, make(map[memoKey]int))
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
 }
 
-func (r Record) generateStates(states SpringStates, stateIdx, sequenceIdx int, memo map[memoKey]int) (res int) {
-	if stateIdx > len(states)-1 && sequenceIdx > len(r.sequences)-1 {
+func (r Record) generateStates(
+	ctx context.Context,
+	states SpringStates,
+	stateIdx, sequenceIdx int,
+	memo map[memoKey]int,
+) (res int) {
+	if ctx.Err() != nil {
+		// The count is thrown away once the context is done, so there is no point in finishing it
+		return 0
+	} else if stateIdx > len(states)-1 && sequenceIdx > len(r.sequences)-1 {
 		return 1
 	} else if stateIdx > len(states)-1 {
 		return 0
@@ -95,22 +109,22 @@ func (r Record) generateStates(states SpringStates, stateIdx, sequenceIdx int, m
 	}
 
 	if states[stateIdx] == SpringStateUnknown {
-		ifDamaged := r.exploreWithState(states, stateIdx, sequenceIdx, memo, SpringStateDamaged)
-		ifOperational := r.exploreWithState(states, stateIdx, sequenceIdx, memo, SpringStateOperational)
+		ifDamaged := r.exploreWithState(ctx, states, stateIdx, sequenceIdx, memo, SpringStateDamaged)
+		ifOperational := r.exploreWithState(ctx, states, stateIdx, sequenceIdx, memo, SpringStateOperational)
 
 		return ifDamaged + ifOperational
 	} else if states[stateIdx] == SpringStateOperational {
 		// If this is damaged, keep going so we can find the rest of the group
-		return r.generateStates(states, stateIdx+1, sequenceIdx, memo)
+		return r.generateStates(ctx, states, stateIdx+1, sequenceIdx, memo)
 	} else if states[stateIdx] != SpringStateDamaged {
 		panic(fmt.Sprintf("invalid state %d", states[stateIdx]))
 	}
 
 	if startIdx < 0 && stateIdx < len(states)-1 && states[stateIdx+1] == SpringStateDamaged {
 		// Could be a match, we don't know yet
-		return r.generateStates(states, stateIdx+1, sequenceIdx, memo)
+		return r.generateStates(ctx, states, stateIdx+1, sequenceIdx, memo)
 	} else if startIdx < 0 && stateIdx < len(states)-1 && states[stateIdx+1] == SpringStateUnknown {
-		return r.exploreWithState(states, stateIdx+1, sequenceIdx, memo, SpringStateDamaged)
+		return r.exploreWithState(ctx, states, stateIdx+1, sequenceIdx, memo, SpringStateDamaged)
 	} else if startIdx < 0 {
 		// Can't be a match anymore
 		return 0
@@ -132,63 +146,48 @@ func (r Record) generateStates(states SpringStates, stateIdx, sequenceIdx int, m
 		return 0
 	} else if haveRightDamagedCount && states[stateIdx+1] == SpringStateUnknown {
 		// If we hit an unknown, try to finish this having ended the sequence
-		return r.exploreWithState(states, stateIdx+1, sequenceIdx+1, memo, SpringStateOperational)
+		return r.exploreWithState(ctx, states, stateIdx+1, sequenceIdx+1, memo, SpringStateOperational)
 	} else if haveRightDamagedCount {
 		// We've finished a match successfully, the next is known to be operational
-		return r.generateStates(states, stateIdx+1, sequenceIdx+1, memo)
+		return r.generateStates(ctx, states, stateIdx+1, sequenceIdx+1, memo)
 	} else if stateIdx < len(states)-1 && states[stateIdx+1] == SpringStateOperational {
 		// This doesn't match, and we've hit the end, so nothing else we can try
 		return 0
 	} else if stateIdx < len(states)-1 && states[stateIdx+1] == SpringStateUnknown {
-		return r.exploreWithState(states, stateIdx+1, sequenceIdx, memo, SpringStateDamaged)
+		return r.exploreWithState(ctx, states, stateIdx+1, sequenceIdx, memo, SpringStateDamaged)
 	}
 
-	return r.generateStates(states, stateIdx+1, sequenceIdx, memo)
+	return r.generateStates(ctx, states, stateIdx+1, sequenceIdx, memo)
 
 }
 
-func (r Record) exploreWithState(states SpringStates, stateIdx, sequenceIdx int, memo map[memoKey]int, withState SpringState) int {
+func (r Record) exploreWithState(
+	ctx context.Context,
+	states SpringStates,
+	stateIdx, sequenceIdx int,
+	memo map[memoKey]int,
+	withState SpringState,
+) int {
 	updStates := slices.Clone(states)
 	updStates[stateIdx] = withState
-	return r.generateStates(updStates, stateIdx, sequenceIdx, memo)
+	return r.generateStates(ctx, updStates, stateIdx, sequenceIdx, memo)
 }
 
-func main() {
-	if len(os.Args) != 2 && len(os.Args) != 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s inputfile\n", os.Args[0])
-		os.Exit(1)
-	}
-
-	inputFilename := os.Args[1]
-	inputFile, err := os.Open(inputFilename)
-	if err != nil {
-		panic(fmt.Sprintf("could not open input file: %s", err))
-	}
-
-	defer inputFile.Close()
-
-	inputBytes, err := io.ReadAll(inputFile)
-	if err != nil {
-		panic(fmt.Sprintf("could not read input file: %s", err))
-	}
-
-	input := strings.TrimSpace(string(inputBytes))
-	inputLines := strings.Split(input, "\n")
-
-	records, err := parseRecords(inputLines)
-	if err != nil {
-		panic(fmt.Sprintf("failed to parse input: %s", err))
-	}
-
-	fmt.Printf("Part 1: %d\n", part1(records))
-	fmt.Printf("Part 2: %d\n", part2(records))
+func init() {
+	aoc.Register(12, aoc.Solution[[]Record, int]{
+		Parse: func(input string) ([]Record, error) {
+			return parse.Lines(input, parseRecord)
+		},
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func part1(records []Record) int {
-	return evaluate(records)
+func part1(ctx context.Context, records []Record) (int, error) {
+	return evaluate(ctx, records)
 }
 
-func part2(records []Record) int {
+func part2(ctx context.Context, records []Record) (int, error) {
 	repeatedRecords := make([]Record, len(records))
 	for i, originalRecord := range records {
 		repeatedRecords[i] = Record{
@@ -197,10 +196,12 @@ func part2(records []Record) int {
 		}
 	}
 
-	return evaluate(repeatedRecords)
+	return evaluate(ctx, repeatedRecords)
 }
 
-func evaluate(records []Record) int {
+// evaluate sums the possible states of every record. If the context is done before it is, any records that have yet
+// to be counted are skipped, and the context's error is returned.
+func evaluate(ctx context.Context, records []Record) (int, error) {
 	total := 0
 	answerChan := make(chan int)
 	wg := sync.WaitGroup{}
@@ -210,9 +211,13 @@ func evaluate(records []Record) int {
 		wg.Add(1)
 		r := record
 		go func() {
-			possibilities := r.CountPossibleStates()
+			defer wg.Done()
+			possibilities, err := r.CountPossibleStates(ctx)
+			if err != nil {
+				return
+			}
+
 			answerChan <- possibilities
-			wg.Done()
 		}()
 	}
 
@@ -225,7 +230,11 @@ func evaluate(records []Record) int {
 		total += answer
 	}
 
-	return total
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return total, nil
 }
 
 func matchesSequence(states SpringStates, sequences []int) SequenceStatus {
@@ -271,23 +280,19 @@ func matchesSequence(states SpringStates, sequences []int) SequenceStatus {
 	}
 }
 
-func parseRecords(inputLines []string) ([]Record, error) {
-	return tryParse(inputLines, parseRecord)
-}
-
 func parseRecord(inputLine string) (Record, error) {
 	lineRegexp := regexp.MustCompile(`^([#.?]+) ((?:\d+,)*\d+)$`)
-	matches := lineRegexp.FindStringSubmatch(inputLine)
-	if matches == nil {
-		return Record{}, errors.New("malformed input line")
+	captures, err := parse.Match(lineRegexp, inputLine)
+	if err != nil {
+		return Record{}, fmt.Errorf("malformed input line: %w", err)
 	}
 
-	states, err := parseSpringStates(matches[1])
+	states, err := parse.Capture(captures, 1, parseSpringStates)
 	if err != nil {
 		return Record{}, fmt.Errorf("spring state: %w", err)
 	}
 
-	sequences, err := tryParse(strings.Split(matches[2], ","), strconv.Atoi)
+	sequences, err := captures.Ints(2)
 	if err != nil {
 		return Record{}, fmt.Errorf("sequences: %w", err)
 	}
@@ -306,27 +311,13 @@ func parseSpringStates(inputStates string) ([]SpringState, error) {
 		case '?':
 			states[i] = SpringStateUnknown
 		default:
-			return nil, fmt.Errorf("invalid spring state char, %c", char)
+			return nil, parse.AtColumn(fmt.Errorf("invalid spring state char, %c", char), i+1)
 		}
 	}
 
 	return states, nil
 }
 
-func tryParse[T any](items []string, parse func(string) (T, error)) ([]T, error) {
-	res := make([]T, 0, len(items))
-	for i, item := range items {
-		parsed, err := parse(item)
-		if err != nil {
-			return nil, fmt.Errorf("invalid item #%d: %w", i+1, err)
-		}
-
-		res = append(res, parsed)
-	}
-
-	return res, nil
-}
-
 func repeatSliceWithSeparator[T any, S ~[]T](slice S, n int, sep T) S {
 	res := make(S, len(slice)*(n+1)+(n))
 	idx := 0
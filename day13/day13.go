@@ -0,0 +1,228 @@
+package day13
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/grid"
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+// SmudgeComparer is a stateful comparator between two slices. The idea is that for a given chain of comparisons,
+// the two arrays will be allowed to differ by exactly one item, exactly once. All other times, they must be
+// exactly identical.
+type SmudgeComparer struct {
+	haveDoneModification bool
+}
+
+// ComparePossiblySmudgedLine performs the smudged comparison. See struct doc for more details
+func (comparer *SmudgeComparer) ComparePossiblySmudgedLine(a []int, b []int) bool {
+	if comparer.haveDoneModification {
+		return slices.Equal(a, b)
+	}
+
+	_, _, err := findExtraItem(a, b)
+	if errors.Is(err, errSlicesEqual) {
+		return true
+	} else if errors.Is(err, errSlicesDiffer) {
+		return false
+	} else {
+		comparer.haveDoneModification = true
+		return true
+	}
+}
+
+// HaveDoneModification indicates whether or not a modification has been performed during previous runs of
+// / ComparePossiblySmudgedLine
+func (comparer *SmudgeComparer) HaveDoneModification() bool {
+	return comparer.haveDoneModification
+}
+
+var ErrNotMirrored = errors.New("section is not mirrored")
+
+var errSlicesEqual = errors.New("slices are equal")
+var errSlicesDiffer = errors.New("slices differ by more than one element")
+
+func init() {
+	aoc.Register(13, aoc.Solution[[]grid.Grid[bool], int]{
+		Parse: func(input string) ([]grid.Grid[bool], error) {
+			return parse.Sections(input, parsePatternSection)
+		},
+		Part1: part1,
+		Part2: part2,
+	})
+}
+
+func part1(_ context.Context, sections []grid.Grid[bool]) (int, error) {
+	total := 0
+	for i, section := range sections {
+		sectionResults, err := evaluateSection(section)
+		if err != nil {
+			return 0, fmt.Errorf("invalid section %d: %w", i+1, err)
+		}
+
+		total += sectionResults
+	}
+
+	return total, nil
+}
+
+func part2(_ context.Context, sections []grid.Grid[bool]) (int, error) {
+	total := 0
+	for i, section := range sections {
+		sectionResults, err := evaluateSmudgedSection(section)
+		if err != nil {
+			return 0, fmt.Errorf("invalid section %d: %w", i+1, err)
+		}
+
+		total += sectionResults
+	}
+
+	return total, nil
+}
+
+// evaluateSection will summarize the given section according to the mirror rules for part 1
+func evaluateSection(section grid.Grid[bool]) (int, error) {
+	byRow := rockColumnsByRow(section)
+	for row := 0; row < len(byRow)-1; row++ {
+		if isMirroredAcrossAxis(row, byRow, slices.Equal) {
+			return (row + 1) * 100, nil
+		}
+	}
+
+	byCol := rockColumnsByRow(section.Transpose())
+	for col := 0; col < len(byCol)-1; col++ {
+		if isMirroredAcrossAxis(col, byCol, slices.Equal) {
+			return col + 1, nil
+		}
+	}
+
+	return 0, ErrNotMirrored
+}
+
+// evaluateSection will summarize the given section according to the mirror rules for part 2
+func evaluateSmudgedSection(section grid.Grid[bool]) (int, error) {
+	byRow := rockColumnsByRow(section)
+	for row := 0; row < len(byRow)-1; row++ {
+		comparer := SmudgeComparer{}
+		if isMirroredAcrossAxis(row, byRow, comparer.ComparePossiblySmudgedLine) && comparer.haveDoneModification {
+			return (row + 1) * 100, nil
+		}
+	}
+
+	byCol := rockColumnsByRow(section.Transpose())
+	for col := 0; col < len(byCol)-1; col++ {
+		comparer := SmudgeComparer{}
+		if isMirroredAcrossAxis(col, byCol, comparer.ComparePossiblySmudgedLine) && comparer.haveDoneModification {
+			return col + 1, nil
+		}
+	}
+
+	return 0, ErrNotMirrored
+}
+
+// isMirroredAcrossAxis checks, For the given item along an axis, and the perpendicular axis items at each index
+// along that axis, whether or not the surrounding rows could be considered mirrored
+func isMirroredAcrossAxis(axisIdx int, byAxis [][]int, eqFunc func(a []int, b []int) bool) bool {
+	maxAxisIdx := len(byAxis) - 1
+	// Radiate "outwards" from the axis item, and check the corresponding elements
+	for offset := 1; axisIdx+offset <= maxAxisIdx && axisIdx-(offset-1) >= 0; offset++ {
+		axisItems := byAxis[axisIdx-(offset-1)]
+		oppositeItems := byAxis[axisIdx+offset]
+		if !eqFunc(axisItems, oppositeItems) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findExtraItem finds the index of a standalone differing item in each list. If one of the two return values
+// is not negative one, that is the index of the standout item (note that only one will be > -1 at a time).
+// If the two slices are equal, errSlicesEqual is returned. If the two slices differ by more than one element,
+// errSlicesDiffer is returned.
+//
+// NOTE: this function assumes that these lists are sorted
+func findExtraItem(a, b []int) (int, int, error) {
+	if abs(len(a)-len(b)) > 1 {
+		return -1, -1, errSlicesDiffer
+	}
+
+	aCursor := 0
+	bCursor := 0
+	aCandidate := -1
+	bCandidate := -1
+	for aCursor < len(a) && bCursor < len(b) {
+		aItem := a[aCursor]
+		bItem := b[bCursor]
+
+		if aItem == bItem {
+			aCursor++
+			bCursor++
+		} else if aItem < bItem {
+			if aCandidate != -1 || bCandidate != -1 {
+				return -1, -1, errSlicesDiffer
+			}
+
+			aCandidate = aCursor
+			aCursor++
+		} else {
+			if aCandidate != -1 || bCandidate != -1 {
+				return -1, -1, errSlicesDiffer
+			}
+
+			bCandidate = bCursor
+			bCursor++
+		}
+	}
+
+	if aCursor < len(a) && bCursor >= len(b) {
+		return aCursor, -1, nil
+	} else if bCursor >= len(a) && bCursor < len(b) {
+		return -1, bCursor, nil
+	} else if aCandidate == -1 && bCandidate == -1 {
+		return -1, -1, errSlicesEqual
+	}
+
+	return aCandidate, bCandidate, nil
+}
+
+// rockColumnsByRow gets the (sorted) columns of all rocks in each row of the section. To get the rows of all rocks
+// in each column, transpose the section first.
+func rockColumnsByRow(section grid.Grid[bool]) [][]int {
+	res := make([][]int, section.Height())
+	for row := range res {
+		res[row] = []int{}
+		for col, isRock := range section.Row(row) {
+			if isRock {
+				res[row] = append(res[row], col)
+			}
+		}
+	}
+
+	return res
+}
+
+func parsePatternSection(section string) (grid.Grid[bool], error) {
+	return grid.Parse(strings.Split(section, "\n"), func(char rune) (bool, error) {
+		if char == '#' {
+			return true, nil
+		} else if char != '.' {
+			return false, fmt.Errorf("invalid char: %c", char)
+		}
+
+		return false, nil
+	})
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
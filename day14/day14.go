@@ -0,0 +1,217 @@
+package day14
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/grid"
+)
+
+const Part2Cycles = 1000000000
+
+type Tile int
+type Direction int
+
+const (
+	TileEmpty Tile = iota
+	TileRoundRock
+	TileCubeRock
+)
+
+const (
+	DirectionNorth Direction = iota
+	DirectionWest
+	DirectionSouth
+	DirectionEast
+)
+
+func (t Tile) String() string {
+	switch t {
+	case TileEmpty:
+		return "."
+	case TileRoundRock:
+		return "O"
+	case TileCubeRock:
+		return "#"
+	default:
+		panic(fmt.Sprintf("invalid tile value %d", t))
+	}
+}
+
+func init() {
+	aoc.Register(14, aoc.Solution[grid.Grid[Tile], int]{
+		Parse: func(input string) (grid.Grid[Tile], error) {
+			return grid.Parse(strings.Split(input, "\n"), tileForRune)
+		},
+		// Both parts roll the rocks in place, so they must be given their own copy
+		Part1: func(_ context.Context, platform grid.Grid[Tile]) (int, error) {
+			return part1(platform.Clone())
+		},
+		Part2: func(ctx context.Context, platform grid.Grid[Tile]) (int, error) {
+			return part2(ctx, platform.Clone())
+		},
+	})
+}
+
+func part1(inputGrid grid.Grid[Tile]) (int, error) {
+	rollDirection(inputGrid, DirectionNorth)
+
+	return calculateNorthernLoad(inputGrid), nil
+}
+
+func part2(ctx context.Context, inputGrid grid.Grid[Tile]) (int, error) {
+	period := -1
+	previouslySeenStates := map[string]struct{}{}
+	for i := 0; i < Part2Cycles; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		rollCycle(inputGrid)
+		serialized := inputGrid.String()
+
+		_, ok := previouslySeenStates[serialized]
+		if ok {
+			period = i
+			break
+		}
+
+		previouslySeenStates[serialized] = struct{}{}
+	}
+
+	if period == -1 {
+		// if SOMEHOW we did not find a period, I guess we just finished the simulation
+		return calculateNorthernLoad(inputGrid), nil
+	}
+
+	nextCycleIter := Part2Cycles / period * period
+	// finish off the rest
+	for i := nextCycleIter - 1; i <= Part2Cycles; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		rollCycle(inputGrid)
+	}
+
+	return calculateNorthernLoad(inputGrid), nil
+}
+
+func calculateNorthernLoad(inputGrid grid.Grid[Tile]) int {
+	load := 0
+	for _, position := range inputGrid.FindAll(func(tile Tile) bool { return tile == TileRoundRock }) {
+		load += inputGrid.Height() - position.Row
+	}
+
+	return load
+}
+
+// rollCycle will run through all the directions in a cycle and roll in each of them
+func rollCycle(inputGrid grid.Grid[Tile]) {
+	for direction := DirectionNorth; direction <= DirectionEast; direction++ {
+		rollDirection(inputGrid, direction)
+	}
+}
+
+// rollDirection will roll each round rock to the maximum possible position in that direction
+func rollDirection(inputGrid grid.Grid[Tile], direction Direction) {
+	iterateAgainstDirection(inputGrid, direction, func(position grid.Coordinate) {
+		tile := inputGrid.At(position)
+		if tile != TileRoundRock {
+			return
+		}
+
+		lastEmpty := findLastEmptyInDirection(inputGrid, position, direction)
+
+		inputGrid.Set(position, TileEmpty)
+		inputGrid.Set(lastEmpty, TileRoundRock)
+	})
+}
+
+// findLastEmptyInDirection will find the next open position in the given direction.
+// If none are available, the original coordinate is returned (which is sufficient for this puzzle)
+func findLastEmptyInDirection(inputGrid grid.Grid[Tile], position grid.Coordinate, direction Direction) grid.Coordinate {
+	ray := makeRayForDirection(direction)
+	lastEmpty := position
+	cursor := position.Add(ray)
+	for inputGrid.InBounds(cursor) {
+		if inputGrid.At(cursor) == TileEmpty {
+			lastEmpty = cursor
+		} else {
+			break
+		}
+
+		cursor = cursor.Add(ray)
+	}
+
+	return lastEmpty
+}
+
+// iterateAgainstDirection will iterate over the grid in such a way that the iteration order moves against the given
+// direction. This is useful for rolling as we do not want to have to continually recompute our rolls; going
+// backwards is helpful.
+//
+// The first item in any direction is skipped, as it can't be used for rolling
+func iterateAgainstDirection(inputGrid grid.Grid[Tile], direction Direction, fun func(position grid.Coordinate)) {
+	switch direction {
+	case DirectionNorth, DirectionWest:
+		for row := 0; row < inputGrid.Height(); row++ {
+			if row == 0 && direction == DirectionNorth {
+				continue
+			}
+
+			for col := 0; col < inputGrid.Width(); col++ {
+				if col == 0 && direction == DirectionWest {
+					continue
+				}
+
+				fun(grid.Coordinate{Row: row, Col: col})
+			}
+		}
+	case DirectionSouth, DirectionEast:
+		for row := inputGrid.Height() - 1; row >= 0; row-- {
+			if row == inputGrid.Height()-1 && direction == DirectionSouth {
+				continue
+			}
+			for col := inputGrid.Width() - 1; col >= 0; col-- {
+				if col == inputGrid.Width()-1 && direction == DirectionEast {
+					continue
+				}
+				fun(grid.Coordinate{Row: row, Col: col})
+			}
+		}
+	default:
+		panic(fmt.Sprintf("invalid direction %d", direction))
+	}
+}
+
+// makeRayForDirection gets the offset to scan for empty tiles with for the given direction
+func makeRayForDirection(direction Direction) grid.Coordinate {
+	switch direction {
+	case DirectionNorth:
+		return grid.Coordinate{Row: -1, Col: 0}
+	case DirectionSouth:
+		return grid.Coordinate{Row: 1, Col: 0}
+	case DirectionEast:
+		return grid.Coordinate{Row: 0, Col: 1}
+	case DirectionWest:
+		return grid.Coordinate{Row: 0, Col: -1}
+	default:
+		panic(fmt.Sprintf("invalid direction %d", direction))
+	}
+}
+
+func tileForRune(r rune) (Tile, error) {
+	switch r {
+	case '.':
+		return TileEmpty, nil
+	case 'O':
+		return TileRoundRock, nil
+	case '#':
+		return TileCubeRock, nil
+	default:
+		return TileEmpty, fmt.Errorf("invalid tile char %c", r)
+	}
+}
@@ -0,0 +1,180 @@
+package day15
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+)
+
+// Operation is some sort of operation we can perform on a hashmap
+type Operation[V any] func(*HashMap[V])
+
+// makeSetOperation makes an Operation that will set the given key to the given value in the hashmap
+func makeSetOperation[V any](key string, value V) Operation[V] {
+	return func(hm *HashMap[V]) {
+		hm.Set(key, value)
+	}
+}
+
+// makeDeleteOperation makes an Operation that will delete the given key from the hashmap
+func makeDeleteOperation[V any](key string) Operation[V] {
+	return func(hm *HashMap[V]) {
+		hm.Delete(key)
+	}
+}
+
+func init() {
+	verbose := false
+	aoc.Register(15, aoc.Solution[[]string, int]{
+		Parse: func(input string) ([]string, error) {
+			return strings.Split(input, ","), nil
+		},
+		Flags: func(flagSet *flag.FlagSet) {
+			flagSet.BoolVar(&verbose, "verbose", false, "print the boxes after each step of part 2, like the puzzle does")
+		},
+		Part1: part1,
+		Part2: func(_ context.Context, inputElements []string) (int, error) {
+			if verbose {
+				return part2(inputElements, os.Stdout)
+			}
+
+			return part2(inputElements, nil)
+		},
+	})
+}
+
+func part1(_ context.Context, inputElements []string) (int, error) {
+	sum := 0
+	for _, element := range inputElements {
+		sum += hash(element)
+	}
+
+	return sum, nil
+}
+
+// part2 runs every operation, and calculates the power of the lenses that are left. If stepsOut is not nil, the
+// boxes are printed to it after each step.
+func part2(inputElements []string, stepsOut io.Writer) (int, error) {
+	operations := make([]Operation[int], 0, len(inputElements))
+	for _, element := range inputElements {
+		operation, err := parseOperation(element)
+		if err != nil {
+			return 0, fmt.Errorf("could not parse operation %q: %w", element, err)
+		}
+
+		operations = append(operations, operation)
+	}
+
+	hm := NewHashMap[int]()
+	for i, operation := range operations {
+		operation(hm)
+		if stepsOut == nil {
+			continue
+		}
+
+		if i > 0 {
+			fmt.Fprintln(stepsOut)
+		}
+
+		if err := printStep(stepsOut, inputElements[i], hm); err != nil {
+			return 0, fmt.Errorf("print step: %w", err)
+		}
+	}
+
+	return calculatePower(hm), nil
+}
+
+// printStep prints the boxes that have lenses in them, in the format the puzzle uses (e.g. `Box 0: [rn 1] [cm 2]`),
+// under a header naming the step
+func printStep(w io.Writer, step string, hm *HashMap[int]) error {
+	if _, err := fmt.Fprintf(w, "After %q:\n", step); err != nil {
+		return err
+	}
+
+	for i := 0; i < hm.NumBoxes(); i++ {
+		boxEntries, err := hm.EntriesInBox(i)
+		if err != nil {
+			// Can't happen since we're iterating over the known boxes
+			panic(err)
+		}
+
+		if len(boxEntries) == 0 {
+			continue
+		}
+
+		lenses := make([]string, 0, len(boxEntries))
+		for _, item := range boxEntries {
+			lenses = append(lenses, fmt.Sprintf("[%s %d]", item.key, item.value))
+		}
+
+		if _, err := fmt.Fprintf(w, "Box %d: %s\n", i, strings.Join(lenses, " ")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hash(s string) int {
+	return hashModulo(s, 256)
+}
+
+// hashModulo runs the HASH algorithm, but keeps the value below the given modulus, rather than below 256
+func hashModulo(s string, modulus int) int {
+	res := 0
+	for _, char := range s {
+		res += int(char)
+		res *= 17
+		res %= modulus
+	}
+
+	return res
+}
+
+func calculatePower(hm *HashMap[int]) int {
+	power := 0
+	for i := 0; i < hm.NumBoxes(); i++ {
+		boxEntries, err := hm.EntriesInBox(i)
+		if err != nil {
+			// Can't happen since we're iterating over the known array
+			panic(err)
+		}
+
+		boxNumber := i + 1
+		for j, item := range boxEntries {
+			power += boxNumber * (j + 1) * item.value
+		}
+	}
+
+	return power
+}
+
+func parseOperation(s string) (Operation[int], error) {
+	setPattern := regexp.MustCompile(`^([a-z]+)=(\d+)`)
+	deletePattern := regexp.MustCompile(`^([a-z]+)-$`)
+
+	setMatches := setPattern.FindStringSubmatch(s)
+	deleteMatches := deletePattern.FindStringSubmatch(s)
+	if setMatches != nil {
+		key := setMatches[1]
+		value, err := strconv.Atoi(setMatches[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid focal length %s: %w", setMatches[2], err)
+		}
+
+		return makeSetOperation(key, value), nil
+	} else if deleteMatches != nil {
+		key := deleteMatches[1]
+		return makeDeleteOperation[int](key), nil
+	} else {
+		return nil, errors.New("invalid operation string")
+	}
+}
@@ -0,0 +1,63 @@
+package day15
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPart2PrintsStepsLikeThePuzzle(t *testing.T) {
+	expected := `After "rn=1":
+Box 0: [rn 1]
+
+After "cm-":
+Box 0: [rn 1]
+
+After "qp=3":
+Box 0: [rn 1]
+Box 1: [qp 3]
+
+After "cm=2":
+Box 0: [rn 1] [cm 2]
+Box 1: [qp 3]
+
+After "qp-":
+Box 0: [rn 1] [cm 2]
+
+After "pc=4":
+Box 0: [rn 1] [cm 2]
+Box 3: [pc 4]
+
+After "ot=9":
+Box 0: [rn 1] [cm 2]
+Box 3: [pc 4] [ot 9]
+
+After "ab=5":
+Box 0: [rn 1] [cm 2]
+Box 3: [pc 4] [ot 9] [ab 5]
+
+After "pc-":
+Box 0: [rn 1] [cm 2]
+Box 3: [ot 9] [ab 5]
+
+After "pc=6":
+Box 0: [rn 1] [cm 2]
+Box 3: [ot 9] [ab 5] [pc 6]
+
+After "ot=7":
+Box 0: [rn 1] [cm 2]
+Box 3: [ot 7] [ab 5] [pc 6]
+`
+
+	stepsOut := strings.Builder{}
+	inputElements := strings.Split("rn=1,cm-,qp=3,cm=2,qp-,pc=4,ot=9,ab=5,pc-,pc=6,ot=7", ",")
+	power, err := part2(inputElements, &stepsOut)
+	if err != nil {
+		t.Fatalf("Failed to run part 2: %s", err)
+	}
+
+	if power != 145 {
+		t.Fatalf("Got power %d, not 145", power)
+	} else if stepsOut.String() != expected {
+		t.Fatalf("Got steps\n%s\nnot\n%s", stepsOut.String(), expected)
+	}
+}
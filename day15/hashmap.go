@@ -0,0 +1,227 @@
+package day15
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// DefaultNumBoxes is the number of boxes the puzzle's HASHMAP has
+const DefaultNumBoxes = 256
+
+var (
+	ErrBoxOutOfBounds  = errors.New("out of bounds box id")
+	ErrInvalidNumBoxes = errors.New("hash map must have at least one box")
+)
+
+// HashFunc picks the box that a key belongs in, given the number of boxes the map has. It must return a value between
+// zero and numBoxes - 1.
+type HashFunc func(key string, numBoxes int) int
+
+// HashMapOptions configures a HashMap
+type HashMapOptions struct {
+	// NumBoxes is the number of boxes the map starts with
+	NumBoxes int
+	// Hash picks the box each key goes in. If it is nil, HolidayHash is used.
+	Hash HashFunc
+	// MaxLoadFactor is the average number of entries per box that, once passed, will double the number of boxes. If
+	// it is zero, the map never grows.
+	MaxLoadFactor float64
+}
+
+// HashMap is a hash map that keeps its entries in boxes (buckets), each of which holds its entries in the order they
+// were inserted. Setting a key that is already in the map replaces its value without moving it.
+type HashMap[V any] struct {
+	boxes         []*list.List
+	hash          HashFunc
+	maxLoadFactor float64
+	len           int
+	// nextSeq is the sequence number the next inserted entry will get, so that entries can be kept in insertion
+	// order if the map grows
+	nextSeq int
+}
+
+type HashMapItem[V any] struct {
+	key   string
+	value V
+	seq   int
+}
+
+// NewHashMap makes a new hash map with the given value type, which works like the puzzle's HASHMAP
+func NewHashMap[V any]() *HashMap[V] {
+	hm, err := NewHashMapWithOptions[V](HashMapOptions{NumBoxes: DefaultNumBoxes})
+	if err != nil {
+		// Can't happen, the default options are valid
+		panic(err)
+	}
+
+	return hm
+}
+
+// NewHashMapWithOptions makes a new hash map with the given value type, configured by the given options
+func NewHashMapWithOptions[V any](options HashMapOptions) (*HashMap[V], error) {
+	if options.NumBoxes < 1 {
+		return nil, fmt.Errorf("%w (got %d)", ErrInvalidNumBoxes, options.NumBoxes)
+	}
+
+	hash := options.Hash
+	if hash == nil {
+		hash = HolidayHash
+	}
+
+	return &HashMap[V]{
+		boxes:         makeBoxes(options.NumBoxes),
+		hash:          hash,
+		maxLoadFactor: options.MaxLoadFactor,
+	}, nil
+}
+
+// HolidayHash is the puzzle's HASH algorithm, reduced to fit within the given number of boxes. HASH only gives values
+// below 256, so once there are more boxes than that, it is run modulo the number of boxes instead, so that every
+// box can be used.
+func HolidayHash(key string, numBoxes int) int {
+	return hashModulo(key, max(numBoxes, 256)) % numBoxes
+}
+
+// Key gets the key of the entry
+func (item HashMapItem[V]) Key() string {
+	return item.key
+}
+
+// Value gets the value of the entry
+func (item HashMapItem[V]) Value() V {
+	return item.value
+}
+
+// Get gets the value stored at the given key, if there is one
+func (hm *HashMap[V]) Get(key string) (V, bool) {
+	element := hm.find(key)
+	if element == nil {
+		var zero V
+		return zero, false
+	}
+
+	return element.Value.(HashMapItem[V]).value, true
+}
+
+// Set sets the given key to the given value. If the key is already in the map, it keeps its place in its box.
+func (hm *HashMap[V]) Set(key string, value V) {
+	element := hm.find(key)
+	if element != nil {
+		item := element.Value.(HashMapItem[V])
+		item.value = value
+		element.Value = item
+
+		return
+	}
+
+	hm.boxes[hm.boxFor(key)].PushBack(HashMapItem[V]{key: key, value: value, seq: hm.nextSeq})
+	hm.nextSeq++
+	hm.len++
+
+	if hm.maxLoadFactor > 0 && float64(hm.len)/float64(len(hm.boxes)) > hm.maxLoadFactor {
+		hm.resize(len(hm.boxes) * 2)
+	}
+}
+
+// Delete removes the given key from the map, and reports whether it was there
+func (hm *HashMap[V]) Delete(key string) bool {
+	element := hm.find(key)
+	if element == nil {
+		return false
+	}
+
+	hm.boxes[hm.boxFor(key)].Remove(element)
+	hm.len--
+
+	return true
+}
+
+// Len gets the number of entries in the map
+func (hm *HashMap[V]) Len() int {
+	return hm.len
+}
+
+// NumBoxes gets the number of boxes the map has
+func (hm *HashMap[V]) NumBoxes() int {
+	return len(hm.boxes)
+}
+
+// All gets every entry in the map, ordered by box, and then by the order they were inserted into their box
+func (hm *HashMap[V]) All() []HashMapItem[V] {
+	res := make([]HashMapItem[V], 0, hm.len)
+	for _, box := range hm.boxes {
+		res = appendBoxEntries(res, box)
+	}
+
+	return res
+}
+
+// EntriesInBox gets all the entries in the hash map box with the given index
+func (hm *HashMap[V]) EntriesInBox(idx int) ([]HashMapItem[V], error) {
+	if idx < 0 || idx >= len(hm.boxes) {
+		return nil, fmt.Errorf("%w %d", ErrBoxOutOfBounds, idx)
+	}
+
+	entryList := hm.boxes[idx]
+
+	return appendBoxEntries(make([]HashMapItem[V], 0, entryList.Len()), entryList), nil
+}
+
+func (hm *HashMap[V]) find(key string) *list.Element {
+	return findInList(hm.boxes[hm.boxFor(key)], func(item HashMapItem[V]) bool {
+		return item.key == key
+	})
+}
+
+func (hm *HashMap[V]) boxFor(key string) int {
+	box := hm.hash(key, len(hm.boxes))
+	if box < 0 || box >= len(hm.boxes) {
+		panic(fmt.Sprintf("hash function put %q in box %d, but there are only %d boxes", key, box, len(hm.boxes)))
+	}
+
+	return box
+}
+
+// resize moves every entry into a new set of boxes. Entries that end up in the same box are kept in the order they
+// were inserted.
+func (hm *HashMap[V]) resize(numBoxes int) {
+	items := hm.All()
+	slices.SortFunc(items, func(a, b HashMapItem[V]) int {
+		return a.seq - b.seq
+	})
+
+	hm.boxes = makeBoxes(numBoxes)
+	for _, item := range items {
+		hm.boxes[hm.boxFor(item.key)].PushBack(item)
+	}
+}
+
+func makeBoxes(numBoxes int) []*list.List {
+	boxes := make([]*list.List, numBoxes)
+	for i := range boxes {
+		boxes[i] = list.New()
+	}
+
+	return boxes
+}
+
+func appendBoxEntries[V any](entries []HashMapItem[V], box *list.List) []HashMapItem[V] {
+	for cursor := box.Front(); cursor != nil; cursor = cursor.Next() {
+		entries = append(entries, cursor.Value.(HashMapItem[V]))
+	}
+
+	return entries
+}
+
+// findInList finds the given element in the linked list. The element *MUST* be of the type V, or this will panic
+func findInList[V any](l *list.List, isEqual func(V) bool) *list.Element {
+	for cursor := l.Front(); cursor != nil; cursor = cursor.Next() {
+		if isEqual(cursor.Value.(V)) {
+			return cursor
+		}
+	}
+
+	return nil
+}
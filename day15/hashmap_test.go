@@ -0,0 +1,158 @@
+package day15
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func itemKeys[V any](items []HashMapItem[V]) []string {
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.Key())
+	}
+
+	return keys
+}
+
+func assertKeys(t *testing.T, got []string, expected ...string) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("Got keys %v, not %v", got, expected)
+	}
+
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Fatalf("Got keys %v, not %v", got, expected)
+		}
+	}
+}
+
+func TestHashMapGetSetDelete(t *testing.T) {
+	hm := NewHashMap[int]()
+	hm.Set("rn", 1)
+	hm.Set("cm", 2)
+	hm.Set("rn", 3)
+
+	if value, ok := hm.Get("rn"); !ok || value != 3 {
+		t.Fatalf("Got (%d, %t) for rn, not (3, true)", value, ok)
+	} else if hm.Len() != 2 {
+		t.Fatalf("Map has %d entries, not 2", hm.Len())
+	}
+
+	if !hm.Delete("rn") {
+		t.Fatalf("rn was not deleted")
+	} else if hm.Delete("rn") {
+		t.Fatalf("rn was deleted twice")
+	}
+
+	if _, ok := hm.Get("rn"); ok {
+		t.Fatalf("rn is still in the map")
+	} else if hm.Len() != 1 {
+		t.Fatalf("Map has %d entries, not 1", hm.Len())
+	}
+}
+
+func TestHashMapAllIsInBoxAndInsertionOrder(t *testing.T) {
+	hm := NewHashMap[int]()
+	// rn, cm, and qp go in box 0, 0, and 1
+	hm.Set("qp", 3)
+	hm.Set("rn", 1)
+	hm.Set("cm", 2)
+	// Replacing a value should not move it to the back
+	hm.Set("rn", 4)
+
+	assertKeys(t, itemKeys(hm.All()), "rn", "cm", "qp")
+}
+
+func TestHashMapUsesGivenHash(t *testing.T) {
+	hm, err := NewHashMapWithOptions[int](HashMapOptions{
+		NumBoxes: 4,
+		Hash: func(key string, numBoxes int) int {
+			return len(key) % numBoxes
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to make map: %s", err)
+	}
+
+	hm.Set("abc", 1)
+	hm.Set("a", 2)
+	hm.Set("xyz", 3)
+
+	entries, err := hm.EntriesInBox(3)
+	if err != nil {
+		t.Fatalf("Failed to get box: %s", err)
+	}
+
+	assertKeys(t, itemKeys(entries), "abc", "xyz")
+}
+
+func TestHashMapGrowsAndKeepsInsertionOrder(t *testing.T) {
+	hm, err := NewHashMapWithOptions[int](HashMapOptions{
+		NumBoxes: 1,
+		Hash: func(key string, numBoxes int) int {
+			return int(key[0]-'a') % numBoxes
+		},
+		MaxLoadFactor: 2,
+	})
+	if err != nil {
+		t.Fatalf("Failed to make map: %s", err)
+	}
+
+	for i, key := range []string{"c", "b", "a", "d", "e"} {
+		hm.Set(key, i)
+	}
+
+	if hm.NumBoxes() != 4 {
+		t.Fatalf("Map has %d boxes, not 4", hm.NumBoxes())
+	}
+
+	// a and e share a box once there are 4 boxes, and e was inserted last
+	assertKeys(t, itemKeys(hm.All()), "a", "e", "b", "c", "d")
+}
+
+func TestHolidayHashUsesEveryBoxOnceMapGrows(t *testing.T) {
+	hm, err := NewHashMapWithOptions[int](HashMapOptions{NumBoxes: DefaultNumBoxes, MaxLoadFactor: 1})
+	if err != nil {
+		t.Fatalf("Failed to make map: %s", err)
+	}
+
+	for i := 0; i < 2000; i++ {
+		hm.Set(strconv.Itoa(i), i)
+	}
+
+	if hm.NumBoxes() <= DefaultNumBoxes {
+		t.Fatalf("Map has %d boxes, not more than %d", hm.NumBoxes(), DefaultNumBoxes)
+	}
+
+	for idx := DefaultNumBoxes; idx < hm.NumBoxes(); idx++ {
+		entries, err := hm.EntriesInBox(idx)
+		if err != nil {
+			t.Fatalf("Could not get box %d: %s", idx, err)
+		} else if len(entries) > 0 {
+			return
+		}
+	}
+
+	t.Fatalf("No entries are in boxes past %d", DefaultNumBoxes-1)
+}
+
+func TestHashMapBoxBounds(t *testing.T) {
+	hm := NewHashMap[int]()
+	if _, err := hm.EntriesInBox(DefaultNumBoxes - 1); err != nil {
+		t.Fatalf("Could not get last box: %s", err)
+	}
+
+	for _, idx := range []int{-1, DefaultNumBoxes} {
+		if _, err := hm.EntriesInBox(idx); !errors.Is(err, ErrBoxOutOfBounds) {
+			t.Fatalf("Got error %v for box %d, not %v", err, idx, ErrBoxOutOfBounds)
+		}
+	}
+}
+
+func TestHashMapNeedsBoxes(t *testing.T) {
+	if _, err := NewHashMapWithOptions[int](HashMapOptions{NumBoxes: 0}); !errors.Is(err, ErrInvalidNumBoxes) {
+		t.Fatalf("Got error %v, not %v", err, ErrInvalidNumBoxes)
+	}
+}
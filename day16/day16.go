@@ -1,18 +1,21 @@
-package main
+package day16
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"io"
 	"os"
 	"slices"
 	"strings"
 	"sync"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/grid"
 )
 
 type Tile rune
 
 const (
+	TileEmpty              Tile = '.'
 	TileMirrorRight        Tile = '/'
 	TileMirrorLeft         Tile = '\\'
 	TileSplitterVertical   Tile = '|'
@@ -28,20 +31,13 @@ const (
 	DirectionWest
 )
 
-type Coordinate struct {
-	row int
-	col int
-}
-
 type Beam struct {
-	position  Coordinate
+	position  grid.Coordinate
 	direction Direction
 }
 
 type TileGrid struct {
-	Height int
-	Width  int
-	Tiles  map[Coordinate]Tile
+	grid.Grid[Tile]
 }
 
 func (dir Direction) Horizontal() bool {
@@ -52,60 +48,49 @@ func (dir Direction) Vertical() bool {
 	return dir == DirectionNorth || dir == DirectionSouth
 }
 
-func (grid TileGrid) Print() {
-	grid.PrintWithBeams(nil)
+func (tileGrid TileGrid) Print() {
+	tileGrid.PrintWithBeams(nil)
 }
 
-func (grid TileGrid) PrintWithBeams(beams []Beam) {
-	for row := 0; row < grid.Height; row++ {
-		for col := 0; col < grid.Width; col++ {
-			position := Coordinate{row: row, col: col}
-			tile, ok := grid.Tiles[position]
-			beamIdx := slices.IndexFunc(beams, func(beam Beam) bool {
-				return beam.position == position
-			})
-
-			if beamIdx != -1 && !ok {
-				beamAtPosition := beams[beamIdx]
-				switch beamAtPosition.direction {
-				case DirectionNorth:
-					fmt.Print("^")
-				case DirectionSouth:
-					fmt.Print("v")
-				case DirectionWest:
-					fmt.Print("<")
-				case DirectionEast:
-					fmt.Print(">")
-				default:
-					panic(fmt.Sprintf("invalid direction %d", beamAtPosition.direction))
-				}
-			} else if !ok {
-				fmt.Print(".")
-			} else {
-				fmt.Printf("%c", tile)
-			}
+func (tileGrid TileGrid) PrintWithBeams(beams []Beam) {
+	// Printing to stdout is not something we can meaningfully recover from
+	_ = tileGrid.Fprint(os.Stdout, func(position grid.Coordinate, tile Tile) string {
+		beamIdx := slices.IndexFunc(beams, func(beam Beam) bool {
+			return beam.position == position
+		})
+
+		if beamIdx == -1 || tile != TileEmpty {
+			return string(tile)
 		}
-		fmt.Println()
-	}
-}
 
-// InBounds checks if the given position is in bounds of the grid
-func (grid TileGrid) InBounds(position Coordinate) bool {
-	return position.row >= 0 && position.col >= 0 && position.row < grid.Height && position.col < grid.Width
+		beamAtPosition := beams[beamIdx]
+		switch beamAtPosition.direction {
+		case DirectionNorth:
+			return "^"
+		case DirectionSouth:
+			return "v"
+		case DirectionWest:
+			return "<"
+		case DirectionEast:
+			return ">"
+		default:
+			panic(fmt.Sprintf("invalid direction %d", beamAtPosition.direction))
+		}
+	})
 }
 
 // MovedInDirection returns a new beam, which is the result of this beam having moved in the given direction.
 func (beam Beam) MovedInDirection(dir Direction) Beam {
-	updPosition := beam.position
+	var updPosition grid.Coordinate
 	switch dir {
 	case DirectionNorth:
-		updPosition.row--
+		updPosition = beam.position.North()
 	case DirectionSouth:
-		updPosition.row++
+		updPosition = beam.position.South()
 	case DirectionEast:
-		updPosition.col++
+		updPosition = beam.position.East()
 	case DirectionWest:
-		updPosition.col--
+		updPosition = beam.position.West()
 	default:
 		panic(fmt.Sprintf("invalid direction value %d", dir))
 	}
@@ -125,51 +110,37 @@ func (beam Beam) RotatedInDirection(dir Direction) Beam {
 	}
 }
 
-func main() {
-	if len(os.Args) != 2 && len(os.Args) != 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s inputfile\n", os.Args[0])
-		os.Exit(1)
-	}
-
-	inputFilename := os.Args[1]
-	inputFile, err := os.Open(inputFilename)
-	if err != nil {
-		panic(fmt.Sprintf("could not open input file: %s", err))
-	}
-
-	defer inputFile.Close()
-
-	inputBytes, err := io.ReadAll(inputFile)
-	if err != nil {
-		panic(fmt.Sprintf("could not read input file: %s", err))
-	}
-
-	input := strings.TrimSpace(string(inputBytes))
-	inputLines := strings.Split(input, "\n")
-	grid, err := parseTileGrid(inputLines)
-	if err != nil {
-		panic(fmt.Sprintf("failed to parse input: %s", err))
-	}
-
-	fmt.Printf("Part 1: %d\n", part1(grid))
-	fmt.Printf("Part 2: %d\n", part2(grid))
+func init() {
+	aoc.Register(16, aoc.Solution[TileGrid, int]{
+		Parse: func(input string) (TileGrid, error) {
+			return parseTileGrid(strings.Split(input, "\n"))
+		},
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func part1(grid TileGrid) int {
-	startingBeam := Beam{position: Coordinate{row: 0, col: 0}, direction: DirectionEast}
-	return simulate(grid, startingBeam)
+func part1(ctx context.Context, tileGrid TileGrid) (int, error) {
+	startingBeam := Beam{position: grid.Coordinate{Row: 0, Col: 0}, direction: DirectionEast}
+	return simulate(ctx, tileGrid, startingBeam)
 }
 
-func part2(grid TileGrid) int {
-	startingBeams := allStartingBeams(grid)
+func part2(ctx context.Context, tileGrid TileGrid) (int, error) {
+	startingBeams := allStartingBeams(tileGrid)
 	wg := sync.WaitGroup{}
 	answerChan := make(chan int)
 	for _, startingBeam := range startingBeams {
 		wg.Add(1)
 		startingBeam := startingBeam
 		go func() {
-			answerChan <- simulate(grid, startingBeam)
-			wg.Done()
+			defer wg.Done()
+			// Once we've been told to stop, the context's error is returned below, so the energy isn't needed
+			energy, err := simulate(ctx, tileGrid, startingBeam)
+			if err != nil {
+				return
+			}
+
+			answerChan <- energy
 		}()
 	}
 
@@ -183,11 +154,16 @@ func part2(grid TileGrid) int {
 		maxEnergy = max(energy, maxEnergy)
 	}
 
-	return maxEnergy
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return maxEnergy, nil
 }
 
-// simulate will simulate the beam's movement starting at the given beam, returning the number of energized tiles
-func simulate(grid TileGrid, startingBeam Beam) int {
+// simulate will simulate the beam's movement starting at the given beam, returning the number of energized tiles.
+// If the context is done before the beam stops moving, the context's error is returned.
+func simulate(ctx context.Context, tileGrid TileGrid, startingBeam Beam) (int, error) {
 	beams := []Beam{startingBeam}
 	nextBeams := []Beam{}
 	beamHistory := map[Beam]struct{}{
@@ -195,10 +171,14 @@ func simulate(grid TileGrid, startingBeam Beam) int {
 	}
 
 	for len(beams) > 0 {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
 		for _, beam := range beams {
 			updBeam := beam.MovedInDirection(beam.direction)
-			tile, ok := grid.Tiles[updBeam.position]
-			if ok {
+			tile, ok := tileGrid.Lookup(updBeam.position)
+			if ok && tile != TileEmpty {
 				resultingBeams := beamsFromCollision(tile, updBeam)
 				nextBeams = append(nextBeams, resultingBeams...)
 			} else {
@@ -209,7 +189,7 @@ func simulate(grid TileGrid, startingBeam Beam) int {
 		beams = []Beam{}
 		for _, beam := range nextBeams {
 			_, seenBeam := beamHistory[beam]
-			if grid.InBounds(beam.position) && !seenBeam {
+			if tileGrid.InBounds(beam.position) && !seenBeam {
 				beams = append(beams, beam)
 				beamHistory[beam] = struct{}{}
 			}
@@ -218,40 +198,40 @@ func simulate(grid TileGrid, startingBeam Beam) int {
 		nextBeams = []Beam{}
 	}
 
-	visitedPositions := map[Coordinate]struct{}{}
+	visitedPositions := map[grid.Coordinate]struct{}{}
 	for beam := range beamHistory {
 		visitedPositions[beam.position] = struct{}{}
 	}
 
-	return len(visitedPositions)
+	return len(visitedPositions), nil
 }
 
 // allStartingBeams gets all possible starting beams around the edges of the grid
-func allStartingBeams(grid TileGrid) []Beam {
+func allStartingBeams(tileGrid TileGrid) []Beam {
 	startingBeams := []Beam{}
-	for col := 0; col < grid.Width; col++ {
+	for col := 0; col < tileGrid.Width(); col++ {
 		topBeam := Beam{
 			direction: DirectionSouth,
-			position:  Coordinate{row: 0, col: col},
+			position:  grid.Coordinate{Row: 0, Col: col},
 		}
 
 		bottomBeam := Beam{
 			direction: DirectionNorth,
-			position:  Coordinate{row: grid.Height - 1, col: col},
+			position:  grid.Coordinate{Row: tileGrid.Height() - 1, Col: col},
 		}
 
 		startingBeams = append(startingBeams, topBeam, bottomBeam)
 	}
 
-	for row := 0; row < grid.Height; row++ {
+	for row := 0; row < tileGrid.Height(); row++ {
 		leftBeam := Beam{
 			direction: DirectionEast,
-			position:  Coordinate{row: row, col: 0},
+			position:  grid.Coordinate{Row: row, Col: 0},
 		}
 
 		rightBeam := Beam{
 			direction: DirectionWest,
-			position:  Coordinate{row: row, col: grid.Width - 1},
+			position:  grid.Coordinate{Row: row, Col: tileGrid.Width() - 1},
 		}
 
 		startingBeams = append(startingBeams, leftBeam, rightBeam)
@@ -351,34 +331,17 @@ func beamsFromLeftMirror(tile Tile, beam Beam) []Beam {
 }
 
 func parseTileGrid(inputLines []string) (TileGrid, error) {
-	if len(inputLines) == 0 {
-		return TileGrid{}, fmt.Errorf("no tiles in grid")
-	}
-
-	width := len(inputLines[0])
-	height := len(inputLines)
-	tiles := make(map[Coordinate]Tile)
-	for row, line := range inputLines {
-		if len(line) != width {
-			return TileGrid{}, errors.New("lines have unequal lengths")
-		}
-
-		for col, char := range line {
-			switch char {
-			case '.':
-				continue
-			case rune(TileMirrorLeft), rune(TileMirrorRight), rune(TileSplitterHorizontal), rune(TileSplitterVertical):
-				pos := Coordinate{row: row, col: col}
-				tiles[pos] = Tile(char)
-			default:
-				return TileGrid{}, fmt.Errorf("invalid tile character %c", char)
-			}
+	tiles, err := grid.Parse(inputLines, func(char rune) (Tile, error) {
+		switch Tile(char) {
+		case TileEmpty, TileMirrorLeft, TileMirrorRight, TileSplitterHorizontal, TileSplitterVertical:
+			return Tile(char), nil
+		default:
+			return TileEmpty, fmt.Errorf("invalid tile character %c", char)
 		}
+	})
+	if err != nil {
+		return TileGrid{}, err
 	}
 
-	return TileGrid{
-		Height: height,
-		Width:  width,
-		Tiles:  tiles,
-	}, nil
+	return TileGrid{Grid: tiles}, nil
 }
@@ -0,0 +1,258 @@
+package day17
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/grid"
+)
+
+type Direction int
+
+const (
+	DirectionNorth Direction = iota
+	DirectionEast
+	DirectionSouth
+	DirectionWest
+)
+
+type Location struct {
+	Row           int
+	Col           int
+	FromDirection Direction
+	// NumMovesInDirection is the number of blocks the crucible has moved in a row, in the direction it is heading. It
+	// is only zero at the start, before the crucible has moved.
+	NumMovesInDirection int
+}
+
+type VisitedNode struct {
+	Coordinate      Location
+	LeftInDirection Direction
+}
+
+// locationQueue holds the locations that A* has yet to visit, so that it can visit the one with the lowest estimated
+// heat loss next
+type locationQueue interface {
+	Len() int
+	PushItem(loc Location, estimate int) error
+	PopMin() (Location, int)
+	Contains(loc Location) bool
+	DecreaseKey(loc Location, estimate int) error
+}
+
+var _ locationQueue = &IndexedHeap[Location]{}
+
+var (
+	ErrNoPath      = errors.New("no path to the end of the city")
+	ErrOutOfBounds = errors.New("position is outside the city")
+)
+
+// Position gets the position of the location in the grid, without the information about how it was reached
+func (loc Location) Position() grid.Coordinate {
+	return grid.Coordinate{Row: loc.Row, Col: loc.Col}
+}
+
+// Step gets the location one block away in the given direction. It has not moved in that direction yet.
+func (loc Location) Step(dir Direction) Location {
+	next := Location{Row: loc.Row, Col: loc.Col, FromDirection: dir.Opposite()}
+	switch dir {
+	case DirectionNorth:
+		next.Row--
+	case DirectionSouth:
+		next.Row++
+	case DirectionEast:
+		next.Col++
+	case DirectionWest:
+		next.Col--
+	default:
+		panic(fmt.Sprintf("invalid direction value %d", dir))
+	}
+
+	return next
+}
+
+func (dir Direction) Opposite() Direction {
+	switch dir {
+	case DirectionNorth:
+		return DirectionSouth
+	case DirectionSouth:
+		return DirectionNorth
+	case DirectionEast:
+		return DirectionWest
+	case DirectionWest:
+		return DirectionEast
+	default:
+		panic(fmt.Sprintf("invalid direction value %d", dir))
+	}
+}
+
+// Arrow gets the arrow the puzzle uses to show a crucible moving in this direction
+func (dir Direction) Arrow() string {
+	switch dir {
+	case DirectionNorth:
+		return "^"
+	case DirectionSouth:
+		return "v"
+	case DirectionEast:
+		return ">"
+	case DirectionWest:
+		return "<"
+	default:
+		panic(fmt.Sprintf("invalid direction value %d", dir))
+	}
+}
+
+func init() {
+	options := crucibleOptions{}
+	aoc.Register(17, aoc.Solution[grid.Grid[int], int]{
+		Parse: func(input string) (grid.Grid[int], error) {
+			return grid.Parse(strings.Split(input, "\n"), parseTile)
+		},
+		Flags: options.registerFlags,
+		Part1: func(ctx context.Context, city grid.Grid[int]) (int, error) {
+			return leastHeatLoss(ctx, city, options.rules(Crucible), options)
+		},
+		Part2: func(ctx context.Context, city grid.Grid[int]) (int, error) {
+			return leastHeatLoss(ctx, city, options.rules(UltraCrucible), options)
+		},
+		Commands: map[string]aoc.Command[grid.Grid[int]]{
+			"path": pathCommand,
+		},
+	})
+}
+
+func leastHeatLoss(
+	ctx context.Context,
+	city grid.Grid[int],
+	rules CrucibleRules,
+	options crucibleOptions,
+) (int, error) {
+	start, end := options.route(city)
+	heatLoss, _, err := doAStar(ctx, city, rules, start, end)
+
+	return heatLoss, err
+}
+
+// doAStar finds the path from start to end that loses the least heat, for a crucible following the given rules. Along
+// with the heat lost, it returns the path, including both ends. The search gives up with the context's error if the
+// context is done before it finishes.
+func doAStar(
+	ctx context.Context,
+	city grid.Grid[int],
+	rules CrucibleRules,
+	start, end grid.Coordinate,
+) (int, []Location, error) {
+	if err := rules.Validate(); err != nil {
+		return 0, nil, err
+	} else if !city.InBounds(start) {
+		return 0, nil, fmt.Errorf("%w: start %v is not in the city", ErrOutOfBounds, start)
+	} else if !city.InBounds(end) {
+		return 0, nil, fmt.Errorf("%w: end %v is not in the city", ErrOutOfBounds, end)
+	}
+
+	return searchCity(ctx, city, rules, start, end, NewIndexedHeap[Location]())
+}
+
+// searchCity runs the A* search for doAStar, keeping the locations it has yet to visit in the given queue, which must
+// be empty
+func searchCity(
+	ctx context.Context,
+	city grid.Grid[int],
+	rules CrucibleRules,
+	start, end grid.Coordinate,
+	toVisit locationQueue,
+) (int, []Location, error) {
+	// Every block loses at least this much heat, so this can never overestimate the heat left to lose
+	minBlockHeatLoss := slices.Min(city.Row(0))
+	for row := 1; row < city.Height(); row++ {
+		minBlockHeatLoss = min(minBlockHeatLoss, slices.Min(city.Row(row)))
+	}
+
+	heuristic := func(loc Location) int {
+		return loc.Position().ManhattanDistance(end) * minBlockHeatLoss
+	}
+
+	// Each location must also consider the direction it was approached from and how many steps lead up to it. We
+	// haven't moved at the start, so its direction doesn't matter.
+	startingLocation := Location{Row: start.Row, Col: start.Col}
+	shortestDistances := map[Location]int{
+		startingLocation: 0,
+	}
+
+	// predecessors holds the location that each location was reached from on its shortest path
+	predecessors := map[Location]Location{}
+
+	if err := toVisit.PushItem(startingLocation, heuristic(startingLocation)); err != nil {
+		// Can't happen, the heap is empty
+		panic(err)
+	}
+
+	for toVisit.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, err
+		}
+
+		searchPos, _ := toVisit.PopMin()
+		canStop := searchPos == startingLocation || searchPos.NumMovesInDirection >= rules.MinStraight
+		if searchPos.Position() == end && canStop {
+			return shortestDistances[searchPos], buildPath(predecessors, startingLocation, searchPos), nil
+		}
+
+		for _, neighborPos := range rules.nextLocations(searchPos) {
+			if !city.InBounds(neighborPos.Position()) {
+				continue
+			}
+
+			toNeighbor := shortestDistances[searchPos] + city.At(neighborPos.Position())
+			shortestToNeighbor, haveShortest := shortestDistances[neighborPos]
+			if haveShortest && toNeighbor >= shortestToNeighbor {
+				continue
+			}
+
+			shortestDistances[neighborPos] = toNeighbor
+			predecessors[neighborPos] = searchPos
+			estimatedDistance := toNeighbor + heuristic(neighborPos)
+			var err error
+			if toVisit.Contains(neighborPos) {
+				err = toVisit.DecreaseKey(neighborPos, estimatedDistance)
+			} else {
+				err = toVisit.PushItem(neighborPos, estimatedDistance)
+			}
+
+			if err != nil {
+				// Can't happen, we only push items that aren't in the heap, and we only get here if the distance,
+				// and therefore the estimate, went down
+				panic(err)
+			}
+		}
+	}
+
+	return 0, nil, ErrNoPath
+}
+
+// buildPath follows the predecessors back from the end to the start, and gives the path between them
+func buildPath(predecessors map[Location]Location, start, end Location) []Location {
+	path := []Location{end}
+	for cursor := end; cursor != start; {
+		cursor = predecessors[cursor]
+		path = append(path, cursor)
+	}
+
+	slices.Reverse(path)
+
+	return path
+}
+
+func parseTile(char rune) (int, error) {
+	tileValue, err := strconv.Atoi(string(char))
+	if err != nil {
+		return 0, fmt.Errorf("invalid digit %c: %w", char, err)
+	}
+
+	return tileValue, nil
+}
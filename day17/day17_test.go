@@ -0,0 +1,223 @@
+package day17
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ollien/advent-of-code-2023/grid"
+)
+
+// randomCity makes a city the size of a real puzzle input, with random heat loss in each block
+func randomCity(tb testing.TB) grid.Grid[int] {
+	random := rand.New(rand.NewSource(17))
+	rows := make([][]int, 141)
+	for i := range rows {
+		rows[i] = make([]int, 141)
+		for j := range rows[i] {
+			rows[i][j] = random.Intn(9) + 1
+		}
+	}
+
+	city, err := grid.FromRows(rows)
+	if err != nil {
+		tb.Fatalf("Failed to make city: %s", err)
+	}
+
+	return city
+}
+
+func parseCity(t *testing.T, rawCity string) grid.Grid[int] {
+	city, err := grid.Parse(strings.Split(rawCity, "\n"), parseTile)
+	if err != nil {
+		t.Fatalf("Failed to parse city: %s", err)
+	}
+
+	return city
+}
+
+func TestPathsLoseTheHeatTheyReport(t *testing.T) {
+	city := parseCity(t, `2413432311323
+3215453535623
+3255245654254
+3446585845452
+4546657867536
+1438598798454
+4457876987766
+3637877979653
+4654967986887
+4564679986453
+1224686865563
+2546548887735
+4322674655533`)
+
+	end := grid.Coordinate{Row: city.Height() - 1, Col: city.Width() - 1}
+	tests := []struct {
+		name     string
+		rules    CrucibleRules
+		expected int
+	}{
+		{name: "crucible", rules: Crucible, expected: 102},
+		{name: "ultra crucible", rules: UltraCrucible, expected: 94},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			heatLoss, path, err := doAStar(context.Background(), city, tt.rules, grid.Coordinate{}, end)
+			if err != nil {
+				t.Fatalf("Failed to find path: %s", err)
+			} else if heatLoss != tt.expected {
+				t.Fatalf("Lost %d heat, not %d", heatLoss, tt.expected)
+			}
+
+			if path[0].Position() != (grid.Coordinate{}) || path[len(path)-1].Position() != end {
+				t.Fatalf("Path goes from %v to %v, not from the start to the end", path[0], path[len(path)-1])
+			}
+
+			pathHeatLoss := 0
+			for i, loc := range path[1:] {
+				if loc.Position().ManhattanDistance(path[i].Position()) != 1 {
+					t.Fatalf("Path jumps from %v to %v", path[i], loc)
+				}
+
+				pathHeatLoss += city.At(loc.Position())
+			}
+
+			if pathHeatLoss != heatLoss {
+				t.Fatalf("Path loses %d heat, not %d", pathHeatLoss, heatLoss)
+			}
+		})
+	}
+}
+
+func TestRenderPathMatchesThePuzzle(t *testing.T) {
+	city := parseCity(t, `111111111111
+999999999991
+999999999991
+999999999991
+999999999991`)
+
+	_, path, err := doAStar(context.Background(), city, UltraCrucible, grid.Coordinate{}, grid.Coordinate{Row: 4, Col: 11})
+	if err != nil {
+		t.Fatalf("Failed to find path: %s", err)
+	}
+
+	rendered := strings.Builder{}
+	if err := RenderPath(&rendered, city, path); err != nil {
+		t.Fatalf("Failed to render path: %s", err)
+	}
+
+	// The ultra crucible can't stop at the end until it has moved four blocks in a row
+	expected := `1>>>>>>>1111
+9999999v9991
+9999999v9991
+9999999v9991
+9999999v>>>>
+`
+	if rendered.String() != expected {
+		t.Fatalf("Got\n%s\nnot\n%s", rendered.String(), expected)
+	}
+}
+
+func TestUltraCrucibleCanLeaveTheStartInAnyDirection(t *testing.T) {
+	// Heading south first loses 8 heat, but heading east first has to cross the 9s
+	city := parseCity(t, "19999\n19999\n19999\n19999\n11111")
+	heatLoss, _, err := doAStar(context.Background(), city, UltraCrucible, grid.Coordinate{}, grid.Coordinate{Row: 4, Col: 4})
+	if err != nil {
+		t.Fatalf("Failed to find path: %s", err)
+	} else if heatLoss != 8 {
+		t.Fatalf("Lost %d heat, not 8", heatLoss)
+	}
+}
+
+func TestBlocksThatLoseNoHeatDoNotMisleadTheSearch(t *testing.T) {
+	city := parseCity(t, "0000\n0200\n0100\n1002")
+	heatLoss, _, err := doAStar(context.Background(), city, Crucible, grid.Coordinate{}, grid.Coordinate{Row: 3, Col: 3})
+	if err != nil {
+		t.Fatalf("Failed to find path: %s", err)
+	} else if heatLoss != 2 {
+		t.Fatalf("Lost %d heat, not 2", heatLoss)
+	}
+}
+
+func TestCrucibleRulesAndRoute(t *testing.T) {
+	tests := []struct {
+		name     string
+		city     string
+		rules    CrucibleRules
+		start    grid.Coordinate
+		end      grid.Coordinate
+		expected int
+	}{
+		{
+			// Going straight up loses 10 heat, but going around the 9 only loses 8
+			name:     "custom start and end",
+			city:     "1111\n9991\n1111",
+			rules:    Crucible,
+			start:    grid.Coordinate{Row: 2, Col: 0},
+			end:      grid.Coordinate{Row: 0, Col: 0},
+			expected: 8,
+		},
+		{
+			// The crucible must overshoot the end, and come back to it after moving 3 blocks
+			name:     "reversing",
+			city:     "11111",
+			rules:    CrucibleRules{MinStraight: 3, MaxStraight: 4, AllowReverse: true},
+			start:    grid.Coordinate{Row: 0, Col: 0},
+			end:      grid.Coordinate{Row: 0, Col: 1},
+			expected: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			heatLoss, _, err := doAStar(context.Background(), parseCity(t, tt.city), tt.rules, tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("Failed to find path: %s", err)
+			} else if heatLoss != tt.expected {
+				t.Fatalf("Lost %d heat, not %d", heatLoss, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCrucibleCannotReachEndWithoutReversing(t *testing.T) {
+	rules := CrucibleRules{MinStraight: 3, MaxStraight: 4}
+	start, end := grid.Coordinate{Row: 0, Col: 0}, grid.Coordinate{Row: 0, Col: 1}
+	_, _, err := doAStar(context.Background(), parseCity(t, "11111"), rules, start, end)
+	if !errors.Is(err, ErrNoPath) {
+		t.Fatalf("Got error %v, not %v", err, ErrNoPath)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	city := randomCity(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := leastHeatLoss(context.Background(), city, Crucible, crucibleOptions{}); err != nil {
+			b.Fatalf("Failed to solve: %s", err)
+		}
+	}
+}
+
+func BenchmarkPart2(b *testing.B) {
+	city := randomCity(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := leastHeatLoss(context.Background(), city, UltraCrucible, crucibleOptions{}); err != nil {
+			b.Fatalf("Failed to solve: %s", err)
+		}
+	}
+}
+
+func TestSearchStopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := doAStar(ctx, randomCity(t), Crucible, grid.Coordinate{}, grid.Coordinate{Row: 140, Col: 140})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Got error %v, not %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,122 @@
+package day17
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrAlreadyInHeap  = errors.New("item is already in the heap")
+	ErrNotInHeap      = errors.New("item is not in the heap")
+	ErrPriorityRaised = errors.New("new priority is higher than the current one")
+)
+
+// IndexedHeap is a min-heap that keeps track of where each of its items are, so that it can check if it holds an
+// item in O(1), and lower an item's priority in O(log n)
+type IndexedHeap[T comparable] struct {
+	data []heapItem[T]
+	// indices holds the position of each item in data
+	indices map[T]int
+}
+
+type heapItem[T any] struct {
+	item     T
+	priority int
+}
+
+var _ heap.Interface = &IndexedHeap[int]{}
+
+func NewIndexedHeap[T comparable]() *IndexedHeap[T] {
+	return &IndexedHeap[T]{
+		data:    []heapItem[T]{},
+		indices: map[T]int{},
+	}
+}
+
+func (h *IndexedHeap[T]) Len() int {
+	return len(h.data)
+}
+
+func (h *IndexedHeap[T]) Less(i, j int) bool {
+	return h.data[i].priority < h.data[j].priority
+}
+
+func (h *IndexedHeap[T]) Swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+	h.indices[h.data[i].item] = i
+	h.indices[h.data[j].item] = j
+}
+
+// Push pushes an item to the end of the underlying list. This is NOT a heap push. Check PushItem
+func (h *IndexedHeap[T]) Push(x any) {
+	item := x.(heapItem[T])
+	h.indices[item.item] = len(h.data)
+	h.data = append(h.data, item)
+}
+
+// Pop will pop the last element from the end of the underlying list. This is NOT a heap pop. Check
+// PopMin
+func (h *IndexedHeap[T]) Pop() any {
+	if len(h.data) == 0 {
+		return nil
+	}
+
+	back := h.data[len(h.data)-1]
+	delete(h.indices, back.item)
+	h.data = h.data[:len(h.data)-1]
+
+	return back
+}
+
+// PushItem is like heap.Push (required by the heap.Interface), but is type-safe, and takes the item's priority.
+// Items may only be in the heap once; use DecreaseKey to change the priority of an item already in the heap.
+func (h *IndexedHeap[T]) PushItem(item T, priority int) error {
+	if h.Contains(item) {
+		return fmt.Errorf("%w: %v", ErrAlreadyInHeap, item)
+	}
+
+	heap.Push(h, heapItem[T]{item: item, priority: priority})
+
+	return nil
+}
+
+// PopMin is like heap.Pop (required by the heap.Interface), but is type-safe, and gives the item's priority along
+// with it. The heap must not be empty.
+func (h *IndexedHeap[T]) PopMin() (T, int) {
+	popped := heap.Pop(h).(heapItem[T])
+
+	return popped.item, popped.priority
+}
+
+// Contains checks if the item is in the heap
+func (h *IndexedHeap[T]) Contains(item T) bool {
+	_, exists := h.indices[item]
+
+	return exists
+}
+
+// Priority gets the priority of the item, if it is in the heap
+func (h *IndexedHeap[T]) Priority(item T) (int, bool) {
+	idx, exists := h.indices[item]
+	if !exists {
+		return 0, false
+	}
+
+	return h.data[idx].priority, true
+}
+
+// DecreaseKey lowers the priority of an item that is already in the heap
+func (h *IndexedHeap[T]) DecreaseKey(item T, priority int) error {
+	idx, exists := h.indices[item]
+	if !exists {
+		return fmt.Errorf("%w: %v", ErrNotInHeap, item)
+	} else if priority > h.data[idx].priority {
+		return fmt.Errorf("%w (%d > %d)", ErrPriorityRaised, priority, h.data[idx].priority)
+	}
+
+	h.data[idx].priority = priority
+	heap.Fix(h, idx)
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package day17
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ollien/advent-of-code-2023/grid"
+)
+
+// comparatorHeap is the heap that A* used before IndexedHeap, kept so the two can be benchmarked against each other.
+// Its comparator reads each location's estimate from a map, so lowering an estimate doesn't move the location.
+type comparatorHeap struct {
+	data      []Location
+	exists    map[Location]struct{}
+	estimates map[Location]int
+}
+
+var _ heap.Interface = &comparatorHeap{}
+
+func newComparatorHeap() *comparatorHeap {
+	return &comparatorHeap{
+		data:      []Location{},
+		exists:    map[Location]struct{}{},
+		estimates: map[Location]int{},
+	}
+}
+
+func (h *comparatorHeap) Len() int {
+	return len(h.data)
+}
+
+func (h *comparatorHeap) Less(i, j int) bool {
+	return h.estimates[h.data[i]] < h.estimates[h.data[j]]
+}
+
+func (h *comparatorHeap) Swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+}
+
+func (h *comparatorHeap) Push(x any) {
+	h.exists[x.(Location)] = struct{}{}
+	h.data = append(h.data, x.(Location))
+}
+
+func (h *comparatorHeap) Pop() any {
+	back := h.data[len(h.data)-1]
+	delete(h.exists, back)
+	h.data = h.data[:len(h.data)-1]
+
+	return back
+}
+
+func (h *comparatorHeap) PushItem(loc Location, estimate int) error {
+	h.estimates[loc] = estimate
+	heap.Push(h, loc)
+
+	return nil
+}
+
+func (h *comparatorHeap) PopMin() (Location, int) {
+	loc := heap.Pop(h).(Location)
+
+	return loc, h.estimates[loc]
+}
+
+func (h *comparatorHeap) Contains(loc Location) bool {
+	_, exists := h.exists[loc]
+
+	return exists
+}
+
+func (h *comparatorHeap) DecreaseKey(loc Location, estimate int) error {
+	h.estimates[loc] = estimate
+
+	return nil
+}
+
+func TestIndexedHeapPopsInPriorityOrderAfterDecreaseKey(t *testing.T) {
+	h := NewIndexedHeap[string]()
+	for item, priority := range map[string]int{"a": 5, "b": 3, "c": 8, "d": 1} {
+		if err := h.PushItem(item, priority); err != nil {
+			t.Fatalf("Failed to push %s: %s", item, err)
+		}
+	}
+
+	if err := h.DecreaseKey("c", 2); err != nil {
+		t.Fatalf("Failed to decrease key: %s", err)
+	}
+
+	expected := []string{"d", "c", "b", "a"}
+	for _, expectedItem := range expected {
+		if !h.Contains(expectedItem) {
+			t.Fatalf("Heap does not contain %s", expectedItem)
+		}
+
+		item, _ := h.PopMin()
+		if item != expectedItem {
+			t.Fatalf("Popped %s, not %s", item, expectedItem)
+		} else if h.Contains(item) {
+			t.Fatalf("Heap still contains %s after popping it", item)
+		}
+	}
+}
+
+func TestIndexedHeapRejectsInvalidUpdates(t *testing.T) {
+	h := NewIndexedHeap[string]()
+	if err := h.PushItem("a", 5); err != nil {
+		t.Fatalf("Failed to push: %s", err)
+	}
+
+	if err := h.PushItem("a", 1); !errors.Is(err, ErrAlreadyInHeap) {
+		t.Fatalf("Got error %v, not %v", err, ErrAlreadyInHeap)
+	} else if err := h.DecreaseKey("a", 6); !errors.Is(err, ErrPriorityRaised) {
+		t.Fatalf("Got error %v, not %v", err, ErrPriorityRaised)
+	} else if err := h.DecreaseKey("b", 1); !errors.Is(err, ErrNotInHeap) {
+		t.Fatalf("Got error %v, not %v", err, ErrNotInHeap)
+	}
+
+	if priority, ok := h.Priority("a"); !ok || priority != 5 {
+		t.Fatalf("Got priority (%d, %t), not (5, true)", priority, ok)
+	}
+}
+
+func BenchmarkHeaps(b *testing.B) {
+	city := randomCity(b)
+	end := grid.Coordinate{Row: city.Height() - 1, Col: city.Width() - 1}
+	crucibles := []struct {
+		name  string
+		rules CrucibleRules
+	}{
+		{name: "part1", rules: Crucible},
+		{name: "part2", rules: UltraCrucible},
+	}
+
+	heaps := []struct {
+		name     string
+		newQueue func() locationQueue
+	}{
+		{name: "comparator", newQueue: func() locationQueue { return newComparatorHeap() }},
+		{name: "indexed", newQueue: func() locationQueue { return NewIndexedHeap[Location]() }},
+	}
+
+	for _, crucible := range crucibles {
+		for _, h := range heaps {
+			b.Run(crucible.name+"/"+h.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_, _, err := searchCity(context.Background(), city, crucible.rules, grid.Coordinate{}, end, h.newQueue())
+					if err != nil {
+						b.Fatalf("Failed to find path: %s", err)
+					}
+				}
+			})
+		}
+	}
+}
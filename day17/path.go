@@ -0,0 +1,71 @@
+package day17
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ollien/advent-of-code-2023/grid"
+)
+
+var ErrInvalidPart = errors.New("invalid part")
+
+// pathCommand draws the path that loses the least heat over the city, using the crucible from the given part. The
+// crucible can be changed with the same flags as when running the parts.
+func pathCommand(city grid.Grid[int], args []string, out io.Writer) error {
+	flagSet := flag.NewFlagSet("path", flag.ContinueOnError)
+	part := flagSet.Int("part", 1, "the part whose crucible should be moved (1 or 2)")
+	options := crucibleOptions{}
+	options.registerFlags(flagSet)
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	var rules CrucibleRules
+	switch *part {
+	case 1:
+		rules = options.rules(Crucible)
+	case 2:
+		rules = options.rules(UltraCrucible)
+	default:
+		return fmt.Errorf("%w %d", ErrInvalidPart, *part)
+	}
+
+	start, end := options.route(city)
+	heatLoss, path, err := doAStar(context.Background(), city, rules, start, end)
+	if err != nil {
+		return err
+	}
+
+	if err := RenderPath(out, city, path); err != nil {
+		return fmt.Errorf("render path: %w", err)
+	}
+
+	fmt.Fprintf(out, "Heat loss: %d\n", heatLoss)
+
+	return nil
+}
+
+// RenderPath draws the city like the puzzle does, with an arrow over each block on the path showing the direction the
+// crucible moved into it. The block the path starts on keeps its heat loss.
+func RenderPath(w io.Writer, city grid.Grid[int], path []Location) error {
+	arrows := make(map[grid.Coordinate]string, len(path))
+	for i, loc := range path {
+		if i == 0 {
+			continue
+		}
+
+		arrows[loc.Position()] = loc.FromDirection.Opposite().Arrow()
+	}
+
+	return city.Fprint(w, func(position grid.Coordinate, heatLoss int) string {
+		if arrow, ok := arrows[position]; ok {
+			return arrow
+		}
+
+		return strconv.Itoa(heatLoss)
+	})
+}
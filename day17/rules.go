@@ -0,0 +1,176 @@
+package day17
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/ollien/advent-of-code-2023/grid"
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+// CrucibleRules describe how a crucible is allowed to move through the city
+type CrucibleRules struct {
+	// MinStraight is the number of blocks the crucible must move in a direction before it can turn, or stop at the end
+	MinStraight int
+	// MaxStraight is the most blocks the crucible can move in a direction before it must turn
+	MaxStraight int
+	// AllowReverse allows the crucible to turn around, as well as left and right
+	AllowReverse bool
+}
+
+var (
+	// Crucible is the crucible from part 1
+	Crucible = CrucibleRules{MinStraight: 1, MaxStraight: 3}
+	// UltraCrucible is the crucible from part 2
+	UltraCrucible = CrucibleRules{MinStraight: 4, MaxStraight: 10}
+)
+
+var (
+	ErrInvalidRules      = errors.New("invalid crucible rules")
+	ErrInvalidCoordinate = errors.New("invalid coordinate")
+)
+
+// crucibleOptions holds the flags that change how the crucible moves, and where. Any that are not set keep the
+// defaults for the part being run.
+type crucibleOptions struct {
+	minStraight  *int
+	maxStraight  *int
+	allowReverse bool
+	start        *grid.Coordinate
+	end          *grid.Coordinate
+}
+
+// Validate checks that a crucible following these rules is able to move
+func (rules CrucibleRules) Validate() error {
+	if rules.MaxStraight < 1 {
+		return fmt.Errorf(
+			"%w: crucible must be able to move at least one block (max straight is %d)",
+			ErrInvalidRules,
+			rules.MaxStraight,
+		)
+	} else if rules.MinStraight > rules.MaxStraight {
+		return fmt.Errorf(
+			"%w: min straight (%d) is more than max straight (%d)",
+			ErrInvalidRules,
+			rules.MinStraight,
+			rules.MaxStraight,
+		)
+	}
+
+	return nil
+}
+
+// nextLocations gets every location the crucible can move to from the given one, including those outside the city
+func (rules CrucibleRules) nextLocations(loc Location) []Location {
+	res := make([]Location, 0, 4)
+	heading := loc.FromDirection.Opposite()
+	for _, direction := range []Direction{DirectionNorth, DirectionEast, DirectionSouth, DirectionWest} {
+		next := loc.Step(direction)
+		switch {
+		case loc.NumMovesInDirection == 0:
+			// The crucible hasn't moved yet, so it can go anywhere
+			next.NumMovesInDirection = 1
+		case direction == heading:
+			if loc.NumMovesInDirection >= rules.MaxStraight {
+				continue
+			}
+
+			next.NumMovesInDirection = loc.NumMovesInDirection + 1
+		case direction == loc.FromDirection && !rules.AllowReverse:
+			continue
+		default:
+			if loc.NumMovesInDirection < rules.MinStraight {
+				continue
+			}
+
+			next.NumMovesInDirection = 1
+		}
+
+		res = append(res, next)
+	}
+
+	return res
+}
+
+// registerFlags registers the flags on the given flag set, and resets any that were set before
+func (options *crucibleOptions) registerFlags(flagSet *flag.FlagSet) {
+	*options = crucibleOptions{}
+	flagSet.Func(
+		"minstraight",
+		"the number of blocks the crucible must move before turning (default: the part's crucible)",
+		intSetter(&options.minStraight),
+	)
+	flagSet.Func(
+		"maxstraight",
+		"the most blocks the crucible can move before turning (default: the part's crucible)",
+		intSetter(&options.maxStraight),
+	)
+	flagSet.BoolVar(&options.allowReverse, "reverse", false, "allow the crucible to turn around")
+	flagSet.Func("start", "the block the crucible starts at, as row,col (default 0,0)", coordinateSetter(&options.start))
+	flagSet.Func(
+		"end",
+		"the block the crucible must get to, as row,col (default: the bottom right of the city)",
+		coordinateSetter(&options.end),
+	)
+}
+
+// rules gets the rules for the crucible, starting from the given defaults
+func (options crucibleOptions) rules(defaults CrucibleRules) CrucibleRules {
+	rules := defaults
+	if options.minStraight != nil {
+		rules.MinStraight = *options.minStraight
+	}
+
+	if options.maxStraight != nil {
+		rules.MaxStraight = *options.maxStraight
+	}
+
+	rules.AllowReverse = rules.AllowReverse || options.allowReverse
+
+	return rules
+}
+
+// route gets the blocks the crucible must travel between in the given city
+func (options crucibleOptions) route(city grid.Grid[int]) (grid.Coordinate, grid.Coordinate) {
+	start := grid.Coordinate{Row: 0, Col: 0}
+	if options.start != nil {
+		start = *options.start
+	}
+
+	end := grid.Coordinate{Row: city.Height() - 1, Col: city.Width() - 1}
+	if options.end != nil {
+		end = *options.end
+	}
+
+	return start, end
+}
+
+func intSetter(dst **int) func(string) error {
+	return func(s string) error {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+
+		*dst = &n
+
+		return nil
+	}
+}
+
+func coordinateSetter(dst **grid.Coordinate) func(string) error {
+	return func(s string) error {
+		components, err := parse.Ints(s)
+		if err != nil {
+			return err
+		} else if len(components) != 2 {
+			return fmt.Errorf("%w %q, expected row,col", ErrInvalidCoordinate, s)
+		}
+
+		*dst = &grid.Coordinate{Row: components[0], Col: components[1]}
+
+		return nil
+	}
+}
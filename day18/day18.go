@@ -1,14 +1,15 @@
-package main
+package day18
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"os"
 	"regexp"
 	"slices"
 	"strconv"
-	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
 )
 
 type Direction int
@@ -68,59 +69,39 @@ func (r Range) End() Coordinate {
 	return r.end
 }
 
-func main() {
-	if len(os.Args) != 2 && len(os.Args) != 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s inputfile\n", os.Args[0])
-		os.Exit(1)
-	}
-
-	inputFilename := os.Args[1]
-	inputFile, err := os.Open(inputFilename)
-	if err != nil {
-		panic(fmt.Sprintf("could not open input file: %s", err))
-	}
-
-	defer inputFile.Close()
-
-	inputBytes, err := io.ReadAll(inputFile)
-	if err != nil {
-		panic(fmt.Sprintf("could not read input file: %s", err))
-	}
-
-	input := strings.TrimSpace(string(inputBytes))
-	inputLines := strings.Split(input, "\n")
-	plans, err := parsePlans(inputLines)
-	if err != nil {
-		panic(fmt.Sprintf("failed to parse input: %s", err))
-	}
-
-	fmt.Printf("Part 1: %d\n", part1(plans))
-	fmt.Printf("Part 2: %d\n", part2(plans))
+func init() {
+	aoc.Register(18, aoc.Solution[[]Plan, int64]{
+		Parse: func(input string) ([]Plan, error) {
+			return parse.Lines(input, parsePlan)
+		},
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func part1(plans []Plan) int64 {
+func part1(_ context.Context, plans []Plan) (int64, error) {
 	drawn := drawPlans(plans)
 	verts, err := findVerts(drawn)
 	if err != nil {
-		panic(err)
+		return 0, fmt.Errorf("find vertices: %w", err)
 	}
 
-	return shoelaceArea(verts)
+	return shoelaceArea(verts), nil
 }
 
-func part2(plans []Plan) int64 {
+func part2(_ context.Context, plans []Plan) (int64, error) {
 	updPlans, err := convertPlansForPart2(plans)
 	if err != nil {
-		panic(err)
+		return 0, fmt.Errorf("convert plans: %w", err)
 	}
 
 	drawn := drawPlans(updPlans)
 	verts, err := findVerts(drawn)
 	if err != nil {
-		panic(err)
+		return 0, fmt.Errorf("find vertices: %w", err)
 	}
 
-	return shoelaceArea(verts)
+	return shoelaceArea(verts), nil
 }
 
 func shoelaceArea(verts []Coordinate) int64 {
@@ -222,32 +203,27 @@ func convertPlansForPart2(plans []Plan) ([]Plan, error) {
 	return res, nil
 }
 
-func parsePlans(inputLines []string) ([]Plan, error) {
-	return tryParse(inputLines, parsePlan)
-}
-
 func parsePlan(rawPlan string) (Plan, error) {
 	pattern := regexp.MustCompile(`^([RUDL]) (\d+) \(#([a-z0-f]+)\)`)
-	matches := pattern.FindStringSubmatch(rawPlan)
-	if matches == nil {
-		return Plan{}, fmt.Errorf("malformed pattern")
+	captures, err := parse.Match(pattern, rawPlan)
+	if err != nil {
+		return Plan{}, fmt.Errorf("malformed pattern: %w", err)
 	}
 
-	direction, err := directionFromAcronym(matches[1])
+	direction, err := parse.Capture(captures, 1, directionFromAcronym)
 	if err != nil {
-		return Plan{}, fmt.Errorf("invalid direction %s: %w", matches[1], err)
+		return Plan{}, fmt.Errorf("invalid direction %s: %w", captures.Group(1), err)
 	}
 
-	count, err := strconv.Atoi(matches[2])
+	count, err := captures.Int(2)
 	if err != nil {
-		// Can't happen by the expression pattern
-		panic(fmt.Sprintf("failed to parse %s as number: %s", matches[2], err))
+		return Plan{}, fmt.Errorf("invalid count %s: %w", captures.Group(2), err)
 	}
 
 	return Plan{
 		Direction: direction,
 		Count:     count,
-		ColorCode: matches[3],
+		ColorCode: captures.Group(3),
 	}, nil
 }
 
@@ -281,20 +257,6 @@ func directionFromNumericValue(n string) (Direction, error) {
 	}
 }
 
-func tryParse[T any](items []string, parse func(string) (T, error)) ([]T, error) {
-	res := make([]T, 0, len(items))
-	for i, item := range items {
-		parsed, err := parse(item)
-		if err != nil {
-			return nil, fmt.Errorf("invalid item #%d: %w", i+1, err)
-		}
-
-		res = append(res, parsed)
-	}
-
-	return res, nil
-}
-
 func abs(n int) int {
 	if n < 0 {
 		return -n
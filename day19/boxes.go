@@ -0,0 +1,192 @@
+package day19
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// RatingBox is a set of parts, given by the range each of their ratings falls in
+type RatingBox map[PartRatingType]Range
+
+// boxesCommand prints every box of parts that the workflows accept. The output is text unless --format json is given.
+func boxesCommand(system System, args []string, out io.Writer) error {
+	flagSet := flag.NewFlagSet("boxes", flag.ContinueOnError)
+	format := flagSet.String("format", "text", "the format to print the boxes in (text or json)")
+	bounds := DefaultRatingBounds
+	registerBoundsFlags(flagSet, &bounds)
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("%w %q", ErrInvalidFormat, *format)
+	}
+
+	boxes, err := AcceptedBoxes(system.rules, system.ratingTypes, bounds)
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(boxes); err != nil {
+			return fmt.Errorf("encode boxes: %w", err)
+		}
+
+		return nil
+	}
+
+	for _, box := range boxes {
+		fmt.Fprintln(out, box)
+	}
+
+	return nil
+}
+
+// AcceptedBoxes finds the boxes of parts that the workflows accept, where parts have the given ratings, each within
+// the given bounds. No two boxes overlap, so a part is accepted if and only if exactly one of the boxes contains it.
+func AcceptedBoxes(rules map[string]Rule, ratingTypes []PartRatingType, bounds Range) ([]RatingBox, error) {
+	return acceptedBoxes(rules, StartRuleName, fullBox(ratingTypes, bounds), []string{})
+}
+
+// fullBox makes a box holding every part with the given ratings
+func fullBox(ratingTypes []PartRatingType, bounds Range) RatingBox {
+	box := make(RatingBox, len(ratingTypes))
+	for _, ratingType := range ratingTypes {
+		box[ratingType] = bounds
+	}
+
+	return box
+}
+
+// AnyBoxContains checks if the given part is in any of the given boxes
+func AnyBoxContains(boxes []RatingBox, part Part) bool {
+	for _, box := range boxes {
+		if box.Contains(part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acceptedBoxes finds the boxes that the given rule accepts, out of the given box. path holds the rules that sent the
+// box here; if any parts in the box have been to this rule before, they would go around forever.
+func acceptedBoxes(rules map[string]Rule, currentRule string, box RatingBox, path []string) ([]RatingBox, error) {
+	if currentRule == RejectedDestination || box.Empty() {
+		return []RatingBox{}, nil
+	} else if currentRule == AcceptedDestination {
+		return []RatingBox{box}, nil
+	} else if cycleStart := slices.Index(path, currentRule); cycleStart != -1 {
+		cycle := append(slices.Clone(path[cycleStart:]), currentRule)
+
+		return nil, fmt.Errorf("%w: %s", ErrWorkflowCycle, strings.Join(cycle, " -> "))
+	}
+
+	rule, ok := rules[currentRule]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrMissingRule, currentRule)
+	}
+
+	boxes := []RatingBox{}
+	culledBox := cloneMap(box)
+	for _, condition := range rule.Conditions {
+		affectedRange, ok := culledBox[condition.PartRatingType]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s checks %q", ErrUnknownRatingType, currentRule, condition.PartRatingType)
+		}
+
+		matchingRange, remainingRange := condition.SplitRange(affectedRange)
+		matchingBox := cloneMap(culledBox)
+		matchingBox[condition.PartRatingType] = matchingRange
+		successBoxes, err := acceptedBoxes(rules, condition.SuccessDestination, matchingBox, append(path, currentRule))
+		if err != nil {
+			return nil, err
+		}
+
+		boxes = append(boxes, successBoxes...)
+		culledBox[condition.PartRatingType] = remainingRange
+	}
+
+	fallbackBoxes, err := acceptedBoxes(rules, rule.FallbackDestination, culledBox, append(path, currentRule))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(boxes, fallbackBoxes...), nil
+}
+
+// Contains checks if the part's ratings all fall within the box. A part that is missing one of the box's ratings is
+// never in it.
+func (box RatingBox) Contains(part Part) bool {
+	for ratingType, r := range box {
+		rating, ok := part.Rating(ratingType)
+		if !ok || rating < r.min || rating > r.max {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Empty checks if there are no parts in the box
+func (box RatingBox) Empty() bool {
+	for _, r := range box {
+		if r.Empty() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Volume counts the number of distinct parts in the box
+func (box RatingBox) Volume() int {
+	volume := 1
+	for _, r := range box {
+		volume *= r.Spread()
+	}
+
+	return volume
+}
+
+// String describes the box as the range of each rating, sorted by the rating's name
+// (e.g. "a=1..2005 m=1..4000 s=1..1350 x=1..1415")
+func (box RatingBox) String() string {
+	ratingTypes := make([]PartRatingType, 0, len(box))
+	for ratingType := range box {
+		ratingTypes = append(ratingTypes, ratingType)
+	}
+
+	slices.Sort(ratingTypes)
+	ranges := make([]string, 0, len(box))
+	for _, ratingType := range ratingTypes {
+		r := box[ratingType]
+		ranges = append(ranges, fmt.Sprintf("%s=%d..%d", ratingType, r.min, r.max))
+	}
+
+	return strings.Join(ranges, " ")
+}
+
+// MarshalJSON encodes the box as an object keyed by rating (e.g. {"x": {"min": 1, "max": 1415}, ...})
+func (box RatingBox) MarshalJSON() ([]byte, error) {
+	ranges := make(map[string]Range, len(box))
+	for ratingType, r := range box {
+		ranges[string(ratingType)] = r
+	}
+
+	return json.Marshal(ranges)
+}
+
+// MarshalJSON encodes the range as an object with its inclusive bounds
+func (r Range) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Min int `json:"min"`
+		Max int `json:"max"`
+	}{Min: r.min, Max: r.max})
+}
@@ -0,0 +1,93 @@
+package day19
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAcceptedBoxesAgreeWithWorkflows(t *testing.T) {
+	system, err := parseSystem(exampleRules + "\n\n" + exampleParts)
+	if err != nil {
+		t.Fatalf("Failed to parse system: %s", err)
+	}
+
+	boxes, err := AcceptedBoxes(system.rules, system.ratingTypes, DefaultRatingBounds)
+	if err != nil {
+		t.Fatalf("Failed to find boxes: %s", err)
+	}
+
+	volume := 0
+	for _, box := range boxes {
+		volume += box.Volume()
+	}
+
+	if volume != 167409079868000 {
+		t.Fatalf("Boxes hold %d parts, not 167409079868000", volume)
+	}
+
+	ruleFuncs := buildRuleFuncs(system.rules)
+	for _, part := range system.parts {
+		accepted, err := isPartAccepted(ruleFuncs, part)
+		if err != nil {
+			t.Fatalf("Failed to process part %v: %s", part, err)
+		}
+
+		if AnyBoxContains(boxes, part) != accepted {
+			t.Fatalf("Boxes disagree with workflows about %v (accepted: %t)", part, accepted)
+		}
+	}
+}
+
+func TestAcceptedBoxesOnlyFailOnCyclesThatPartsCanReach(t *testing.T) {
+	tests := []struct {
+		name           string
+		rules          string
+		expectedVolume int
+		expectedErr    error
+	}{
+		{
+			name:           "loop behind a condition that matches every part",
+			rules:          "in{x>0:A,m<100:in,R}",
+			expectedVolume: 4000 * 4000 * 4000 * 4000,
+		},
+		{
+			name:           "loop on ratings that parts in the workflow cannot have",
+			rules:          "in{x<100:ab,A}\nab{x>200:in,R}",
+			expectedVolume: 3901 * 4000 * 4000 * 4000,
+		},
+		{
+			name:        "loop that parts can go around",
+			rules:       "in{x<100:ab,A}\nab{m>10:in,A}",
+			expectedErr: ErrWorkflowCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := parseRules(tt.rules)
+			if err != nil {
+				t.Fatalf("Failed to parse rules: %s", err)
+			}
+
+			boxes, err := AcceptedBoxes(rules, []PartRatingType{"x", "m", "a", "s"}, DefaultRatingBounds)
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Fatalf("Got error %v, not %v", err, tt.expectedErr)
+				}
+
+				return
+			} else if err != nil {
+				t.Fatalf("Failed to find boxes: %s", err)
+			}
+
+			volume := 0
+			for _, box := range boxes {
+				volume += box.Volume()
+			}
+
+			if volume != tt.expectedVolume {
+				t.Fatalf("Boxes hold %d parts, not %d", volume, tt.expectedVolume)
+			}
+		})
+	}
+}
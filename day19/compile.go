@@ -0,0 +1,184 @@
+package day19
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Jump targets below zero end the program, rather than pointing at another instruction. Targets below
+// missingTarget point at a workflow that was never defined, which is only an error if a part is sent there.
+const (
+	acceptTarget  = -1
+	rejectTarget  = -2
+	missingTarget = -3
+)
+
+type opcode int
+
+const (
+	// opGreater jumps if the rating is greater than the operand, and continues to the next instruction otherwise
+	opGreater opcode = iota
+	// opLess jumps if the rating is less than the operand, and continues to the next instruction otherwise
+	opLess
+	// opJump always jumps
+	opJump
+)
+
+// Program is a set of workflows compiled into a flat list of instructions, so that parts can be sorted without
+// having to look up each workflow by name
+type Program struct {
+	instructions []instruction
+	start        int
+	// ratingTypes holds the ratings parts are expected to have, which the instructions refer to by index
+	ratingTypes []PartRatingType
+	// missingRules holds the destinations that were not defined, which missing targets refer to by index
+	missingRules []string
+}
+
+type instruction struct {
+	op      opcode
+	rating  int
+	operand int
+	target  int
+}
+
+// Compile flattens the workflows into a Program, for parts with the given ratings. Parts that list their ratings in
+// the same order are the quickest to run. As with walking the workflows directly, a destination that is not
+// defined is only an error once a part is sent there.
+func Compile(rules map[string]Rule, ratingTypes []PartRatingType) (Program, error) {
+	names := sortedRuleNames(rules)
+	// Each condition gets an instruction, and then the fallback gets one more
+	starts := make(map[string]int, len(names))
+	size := 0
+	for _, name := range names {
+		starts[name] = size
+		size += len(rules[name].Conditions) + 1
+	}
+
+	missingRules := []string{}
+	target := func(destination string) int {
+		switch destination {
+		case AcceptedDestination:
+			return acceptTarget
+		case RejectedDestination:
+			return rejectTarget
+		}
+
+		start, ok := starts[destination]
+		if !ok {
+			idx := slices.Index(missingRules, destination)
+			if idx == -1 {
+				idx = len(missingRules)
+				missingRules = append(missingRules, destination)
+			}
+
+			return missingTarget - idx
+		}
+
+		return start
+	}
+
+	start := target(StartRuleName)
+
+	instructions := make([]instruction, 0, size)
+	for _, name := range names {
+		rule := rules[name]
+		for _, condition := range rule.Conditions {
+			compiled, err := compileCondition(condition, ratingTypes, target)
+			if err != nil {
+				return Program{}, fmt.Errorf("compile %s: %w", name, err)
+			}
+
+			instructions = append(instructions, compiled)
+		}
+
+		instructions = append(instructions, instruction{op: opJump, target: target(rule.FallbackDestination)})
+	}
+
+	return Program{
+		instructions: instructions,
+		start:        start,
+		ratingTypes:  ratingTypes,
+		missingRules: missingRules,
+	}, nil
+}
+
+func compileCondition(
+	condition RuleCondition,
+	ratingTypes []PartRatingType,
+	target func(string) int,
+) (instruction, error) {
+	compiled := instruction{operand: condition.Operand}
+	switch condition.Operator {
+	case OperatorGreater:
+		compiled.op = opGreater
+	case OperatorLess:
+		compiled.op = opLess
+	default:
+		panic(fmt.Sprintf("invalid operator %c", condition.Operator))
+	}
+
+	compiled.rating = slices.Index(ratingTypes, condition.PartRatingType)
+	if compiled.rating == -1 {
+		return instruction{}, fmt.Errorf("%w %q", ErrUnknownRatingType, condition.PartRatingType)
+	}
+
+	compiled.target = target(condition.SuccessDestination)
+
+	return compiled, nil
+}
+
+// Accepts runs the part through the program, and checks whether it was accepted. The part must have every rating
+// the program was compiled for.
+func (program Program) Accepts(part Part) (bool, error) {
+	// Most parts have few enough ratings that we can keep them on the stack
+	ratingsBuf := [8]int{}
+	ratings := ratingsBuf[:0]
+	for i, ratingType := range program.ratingTypes {
+		// Looking up each rating by name is slow, so we only do it if the part's ratings are out of order
+		if i < len(part.Ratings) && part.Ratings[i].Type == ratingType {
+			ratings = append(ratings, part.Ratings[i].Value)
+			continue
+		}
+
+		rating, ok := part.Rating(ratingType)
+		if !ok {
+			return false, fmt.Errorf("%w %q", ErrMissingRating, ratingType)
+		}
+
+		ratings = append(ratings, rating)
+	}
+
+	pc := program.start
+	if pc <= missingTarget {
+		return false, program.missingRuleError(pc)
+	}
+
+	// Without a cycle, a part can't pass the same instruction twice, so running more than that means we're looping
+	for steps := 0; steps <= len(program.instructions); steps++ {
+		ins := program.instructions[pc]
+		switch {
+		case ins.op == opJump,
+			ins.op == opGreater && ratings[ins.rating] > ins.operand,
+			ins.op == opLess && ratings[ins.rating] < ins.operand:
+			pc = ins.target
+		default:
+			pc++
+			continue
+		}
+
+		if pc == acceptTarget {
+			return true, nil
+		} else if pc == rejectTarget {
+			return false, nil
+		} else if pc <= missingTarget {
+			return false, program.missingRuleError(pc)
+		}
+	}
+
+	return false, fmt.Errorf("%w: part did not finish after %d instructions", ErrWorkflowCycle, len(program.instructions))
+}
+
+func (program Program) missingRuleError(target int) error {
+	return fmt.Errorf("%w %q", ErrMissingRule, program.missingRules[missingTarget-target])
+}
@@ -0,0 +1,141 @@
+package day19
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+const (
+	exampleRules = "in{s<1351:px,qqz}\npx{a<2006:qkq,m>2090:A,rfg}\npv{a>1716:R,A}\nlnx{m>1548:A,A}\nrfg{s<537:gd,x>2440:R,A}\nqs{s>3448:A,lnx}\nqkq{x<1416:A,crn}\ncrn{x>2662:A,R}\nhdj{m>838:A,pv}\ngd{a>3333:R,R}\nqqz{s>2770:qs,m<1801:hdj,R}"
+	exampleParts = "{x=787,m=2655,a=1222,s=2876}\n{x=1679,m=44,a=2067,s=496}\n{x=2036,m=264,a=79,s=2244}\n{x=2461,m=1339,a=466,s=291}\n{x=2127,m=1623,a=2188,s=1013}"
+)
+
+func randomParts(n int) []Part {
+	random := rand.New(rand.NewSource(19))
+	parts := make([]Part, n)
+	for i := range parts {
+		for _, ratingType := range []PartRatingType{"x", "m", "a", "s"} {
+			parts[i].Ratings = append(parts[i].Ratings, Rating{Type: ratingType, Value: random.Intn(4000) + 1})
+		}
+	}
+
+	return parts
+}
+
+// isPartAccepted walks the part through the workflows without compiling them, so that we can check the compiled
+// program against it
+func isPartAccepted(rules map[string]func(Part) (RuleOutcome, error), part Part) (bool, error) {
+	return walkPart(rules, part, nil)
+}
+
+func TestCompiledProgramAgreesWithWorkflows(t *testing.T) {
+	rules, err := parseRules(exampleRules)
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %s", err)
+	}
+
+	program, err := Compile(rules, []PartRatingType{"x", "m", "a", "s"})
+	if err != nil {
+		t.Fatalf("Failed to compile: %s", err)
+	}
+
+	ruleFuncs := buildRuleFuncs(rules)
+	for _, part := range randomParts(10000) {
+		expected, err := isPartAccepted(ruleFuncs, part)
+		if err != nil {
+			t.Fatalf("Failed to process part %v: %s", part, err)
+		}
+
+		accepted, err := program.Accepts(part)
+		if err != nil {
+			t.Fatalf("Failed to run part %v: %s", part, err)
+		}
+
+		if accepted != expected {
+			t.Fatalf("Program gave %t for %v, not %t", accepted, part, expected)
+		}
+	}
+}
+
+func TestCompiledProgramDetectsCycle(t *testing.T) {
+	rules, err := parseRules("in{x>10:aa,A}\naa{m>10:in,R}")
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %s", err)
+	}
+
+	program, err := Compile(rules, []PartRatingType{"x", "m"})
+	if err != nil {
+		t.Fatalf("Failed to compile: %s", err)
+	}
+
+	_, err = program.Accepts(Part{Ratings: []Rating{{Type: "x", Value: 11}, {Type: "m", Value: 11}}})
+	if !errors.Is(err, ErrWorkflowCycle) {
+		t.Fatalf("Got error %v, not %v", err, ErrWorkflowCycle)
+	}
+}
+
+func TestCompiledProgramOnlyFailsOnMissingWorkflowsThatPartsReach(t *testing.T) {
+	// No part can reach ab, so its missing destination should only matter once x can be below 1
+	rules, err := parseRules("in{x<1:ab,m>10:zz,A}\nab{x>0:nope,R}")
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %s", err)
+	}
+
+	program, err := Compile(rules, []PartRatingType{"x", "m"})
+	if err != nil {
+		t.Fatalf("Failed to compile: %s", err)
+	}
+
+	accepted, err := program.Accepts(Part{Ratings: []Rating{{Type: "x", Value: 5}, {Type: "m", Value: 5}}})
+	if err != nil {
+		t.Fatalf("Failed to run part: %s", err)
+	} else if !accepted {
+		t.Fatal("Part was rejected, not accepted")
+	}
+
+	_, err = program.Accepts(Part{Ratings: []Rating{{Type: "x", Value: 5}, {Type: "m", Value: 11}}})
+	if !errors.Is(err, ErrMissingRule) {
+		t.Fatalf("Got error %v, not %v", err, ErrMissingRule)
+	}
+}
+
+func BenchmarkClosures(b *testing.B) {
+	rules, err := parseRules(exampleRules)
+	if err != nil {
+		b.Fatalf("Failed to parse rules: %s", err)
+	}
+
+	parts := randomParts(100000)
+	ruleFuncs := buildRuleFuncs(rules)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, part := range parts {
+			if _, err := isPartAccepted(ruleFuncs, part); err != nil {
+				b.Fatalf("Failed to process part %v: %s", part, err)
+			}
+		}
+	}
+}
+
+func BenchmarkCompiled(b *testing.B) {
+	rules, err := parseRules(exampleRules)
+	if err != nil {
+		b.Fatalf("Failed to parse rules: %s", err)
+	}
+
+	parts := randomParts(100000)
+	program, err := Compile(rules, []PartRatingType{"x", "m", "a", "s"})
+	if err != nil {
+		b.Fatalf("Failed to compile: %s", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, part := range parts {
+			if _, err := program.Accepts(part); err != nil {
+				b.Fatalf("Failed to run part %v: %s", part, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,573 @@
+// TOO HIGH  288057819955899
+
+package day19
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+const (
+	StartRuleName       = "in"
+	AcceptedDestination = "A"
+	RejectedDestination = "R"
+)
+
+var (
+	ErrMissingRule        = errors.New("could not locate rule")
+	ErrWorkflowCycle      = errors.New("workflows send parts around in a cycle")
+	ErrMissingRating      = errors.New("part does not have rating")
+	ErrMismatchedRatings  = errors.New("parts do not all have the same ratings")
+	ErrUnknownRatingType  = errors.New("workflows check a rating that parts do not have")
+	ErrDuplicateRatingKey = errors.New("part has the same rating more than once")
+)
+
+// PartRatingType is the name of one of a part's ratings. The puzzle only uses x, m, a, and s, but any name is allowed.
+type PartRatingType string
+
+// DefaultRatingBounds is the range that every rating falls in, as given by the puzzle
+var DefaultRatingBounds = NewRange(1, 4000)
+
+type ComparisonOperator rune
+
+const (
+	OperatorGreater ComparisonOperator = '>'
+	OperatorLess    ComparisonOperator = '<'
+)
+
+type Part struct {
+	// Ratings holds each of the part's ratings, in the order they were listed
+	Ratings []Rating
+}
+
+type Rating struct {
+	Type  PartRatingType
+	Value int
+}
+
+type Rule struct {
+	Conditions          []RuleCondition
+	FallbackDestination string
+}
+
+type RuleCondition struct {
+	PartRatingType     PartRatingType
+	Operator           ComparisonOperator
+	Operand            int
+	SuccessDestination string
+}
+
+// RuleOutcome is where a rule sent a part, and why
+type RuleOutcome struct {
+	Destination string
+	// Condition is the condition that matched the part, or nil if the rule fell back
+	Condition *RuleCondition
+}
+
+type System struct {
+	rules map[string]Rule
+	parts []Part
+	// ratingTypes holds the types of rating that every part has, in the order they were listed
+	ratingTypes []PartRatingType
+}
+
+type Range struct {
+	// both are inclusive
+	min int
+	max int
+}
+
+// NewRange makes a range between the two given values, inclusive
+func NewRange(low, high int) Range {
+	return Range{min: low, max: high}
+}
+
+// Rating gets the value of the given rating, if the part has it
+func (part Part) Rating(ratingType PartRatingType) (int, bool) {
+	for _, rating := range part.Ratings {
+		if rating.Type == ratingType {
+			return rating.Value, true
+		}
+	}
+
+	return 0, false
+}
+
+// RatingTypes gets the types of each of the part's ratings, in the order they were listed
+func (part Part) RatingTypes() []PartRatingType {
+	ratingTypes := make([]PartRatingType, 0, len(part.Ratings))
+	for _, rating := range part.Ratings {
+		ratingTypes = append(ratingTypes, rating.Type)
+	}
+
+	return ratingTypes
+}
+
+// String describes the part as the puzzle does (e.g. "{x=787,m=2655,a=1222,s=2876}")
+func (part Part) String() string {
+	ratings := make([]string, 0, len(part.Ratings))
+	for _, rating := range part.Ratings {
+		ratings = append(ratings, fmt.Sprintf("%s=%d", rating.Type, rating.Value))
+	}
+
+	return "{" + strings.Join(ratings, ",") + "}"
+}
+
+// MarshalJSON encodes the part as an object keyed by rating, with the ratings in the order they were listed
+func (part Part) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, rating := range part.Ratings {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(string(rating.Type))
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.WriteString(strconv.Itoa(rating.Value))
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+func (operator ComparisonOperator) Compare(a, b int) bool {
+	switch operator {
+	case OperatorGreater:
+		return a > b
+	case OperatorLess:
+		return a < b
+	default:
+		panic(fmt.Sprintf("invalid operator %c", operator))
+	}
+}
+
+// SplitRange splits the given range into the values that match the condition, and those that do not
+func (condition RuleCondition) SplitRange(r Range) (Range, Range) {
+	switch condition.Operator {
+	case OperatorGreater:
+		return r.EliminateLessThanEq(condition.Operand), r.EliminateGreaterThan(condition.Operand)
+	case OperatorLess:
+		return r.EliminateGreaterThanEq(condition.Operand), r.EliminateLessThan(condition.Operand)
+	default:
+		panic(fmt.Sprintf("invalid operator %c", condition.Operator))
+	}
+}
+
+func (condition RuleCondition) String() string {
+	return fmt.Sprintf("%s%c%d:%s", condition.PartRatingType, condition.Operator, condition.Operand, condition.SuccessDestination)
+}
+
+// Destinations gets every destination of the rule, in the order they are checked, ending with the fallback
+func (rule Rule) Destinations() []string {
+	destinations := make([]string, 0, len(rule.Conditions)+1)
+	for _, condition := range rule.Conditions {
+		destinations = append(destinations, condition.SuccessDestination)
+	}
+
+	return append(destinations, rule.FallbackDestination)
+}
+
+func (r Range) Min() int {
+	return r.min
+}
+
+func (r Range) Max() int {
+	return r.max
+}
+
+func (r Range) Empty() bool {
+	return r.min > r.max
+}
+
+func (r Range) EliminateLessThan(n int) Range {
+	if r.Empty() {
+		return r
+	}
+
+	if n > r.min {
+		r.min = n
+	}
+
+	return r
+}
+
+func (r Range) EliminateLessThanEq(n int) Range {
+	return r.EliminateLessThan(n + 1)
+}
+
+func (r Range) EliminateGreaterThan(n int) Range {
+	if r.Empty() {
+		return r
+	}
+
+	if n < r.max {
+		r.max = n
+	}
+
+	return r
+}
+
+func (r Range) EliminateGreaterThanEq(n int) Range {
+	return r.EliminateGreaterThan(n - 1)
+}
+
+func (r Range) Spread() int {
+	if r.Empty() {
+		return 0
+	}
+
+	return r.max - r.min + 1
+}
+
+func (r Range) Intersection(other Range) Range {
+	return Range{
+		min: max(r.min, other.min),
+		max: min(r.max, other.max),
+	}
+}
+
+func init() {
+	bounds := DefaultRatingBounds
+	aoc.Register(19, aoc.Solution[System, int]{
+		Parse: parseSystem,
+		Flags: func(flagSet *flag.FlagSet) {
+			bounds = DefaultRatingBounds
+			registerBoundsFlags(flagSet, &bounds)
+		},
+		Part1: func(_ context.Context, system System) (int, error) {
+			return part1(system.rules, system.ratingTypes, system.parts)
+		},
+		Part2: func(_ context.Context, system System) (int, error) {
+			return part2(system.rules, system.ratingTypes, bounds)
+		},
+		Commands: map[string]aoc.Command[System]{
+			"lint":    lintCommand,
+			"explain": explainCommand,
+			"boxes":   boxesCommand,
+		},
+	})
+}
+
+func part1(rules map[string]Rule, ratingTypes []PartRatingType, parts []Part) (int, error) {
+	program, err := Compile(rules, ratingTypes)
+	if err != nil {
+		return 0, fmt.Errorf("compile workflows: %w", err)
+	}
+
+	acceptedParts := []Part{}
+	for _, part := range parts {
+		accepted, err := program.Accepts(part)
+		if err != nil {
+			return 0, fmt.Errorf("could not process part %v: %w", part, err)
+		}
+
+		if accepted {
+			acceptedParts = append(acceptedParts, part)
+		}
+	}
+
+	acceptedRatings := 0
+	for _, part := range acceptedParts {
+		for _, rating := range part.Ratings {
+			acceptedRatings += rating.Value
+		}
+	}
+
+	return acceptedRatings, nil
+}
+
+func part2(rules map[string]Rule, ratingTypes []PartRatingType, bounds Range) (int, error) {
+	boxes, err := AcceptedBoxes(rules, ratingTypes, bounds)
+	if err != nil {
+		return 0, err
+	}
+
+	combos := 0
+	for _, box := range boxes {
+		combos += box.Volume()
+	}
+
+	return combos, nil
+}
+
+// registerBoundsFlags adds the flags that set the range every rating falls in
+func registerBoundsFlags(flagSet *flag.FlagSet, bounds *Range) {
+	flagSet.IntVar(&bounds.min, "min", bounds.min, "the smallest value any rating can have")
+	flagSet.IntVar(&bounds.max, "max", bounds.max, "the largest value any rating can have")
+}
+
+// walkPart runs the part through the rules until it is accepted or rejected. If onStep is not nil, it is called with
+// the outcome of every rule the part is run through.
+func walkPart(
+	rules map[string]func(Part) (RuleOutcome, error),
+	part Part,
+	onStep func(string, RuleOutcome),
+) (bool, error) {
+	ruleName := StartRuleName
+	visited := map[string]struct{}{}
+	for {
+		rule, ok := rules[ruleName]
+		if !ok {
+			return false, fmt.Errorf("%w %q", ErrMissingRule, ruleName)
+		} else if _, ok := visited[ruleName]; ok {
+			return false, fmt.Errorf("%w: part came back to %s", ErrWorkflowCycle, ruleName)
+		}
+
+		visited[ruleName] = struct{}{}
+		outcome, err := rule(part)
+		if err != nil {
+			return false, fmt.Errorf("run %s: %w", ruleName, err)
+		}
+
+		if onStep != nil {
+			onStep(ruleName, outcome)
+		}
+
+		ruleName = outcome.Destination
+		if ruleName == AcceptedDestination {
+			return true, nil
+		} else if ruleName == RejectedDestination {
+			return false, nil
+		}
+	}
+}
+
+func parseSystem(input string) (System, error) {
+	sections := strings.Split(input, "\n\n")
+	if len(sections) != 2 {
+		return System{}, fmt.Errorf("input did not have expected number of sections (got %d, expected 2)", len(sections))
+	}
+
+	rules, err := parseRules(strings.TrimSpace(sections[0]))
+	if err != nil {
+		return System{}, fmt.Errorf("parse rules: %w", err)
+	}
+
+	// The parts start after the rules, and the blank line that follows them
+	partsLine := strings.Count(sections[0], "\n") + 3
+	parts, err := parse.Lines(strings.TrimSpace(sections[1]), parsePart)
+	if err != nil {
+		return System{}, parse.AtLine(fmt.Errorf("parse parts: %w", err), partsLine)
+	}
+
+	ratingTypes, err := partRatingTypes(parts)
+	if err != nil {
+		return System{}, parse.AtLine(fmt.Errorf("parse parts: %w", err), partsLine)
+	}
+
+	if err := checkRatingTypes(rules, ratingTypes); err != nil {
+		return System{}, fmt.Errorf("parse rules: %w", err)
+	}
+
+	return System{rules: rules, parts: parts, ratingTypes: ratingTypes}, nil
+}
+
+// partRatingTypes gets the rating types that the parts have, making sure that they all have the same ones. Parts
+// may list their ratings in any order, but the order of the first part is used.
+func partRatingTypes(parts []Part) ([]PartRatingType, error) {
+	if len(parts) == 0 {
+		return []PartRatingType{}, nil
+	}
+
+	ratingTypes := parts[0].RatingTypes()
+	for i, part := range parts[1:] {
+		partRatingTypes := part.RatingTypes()
+		sameTypes := len(partRatingTypes) == len(ratingTypes)
+		for _, ratingType := range ratingTypes {
+			sameTypes = sameTypes && slices.Contains(partRatingTypes, ratingType)
+		}
+
+		if !sameTypes {
+			// Line numbers are 1-indexed, and we skipped the first part
+			return nil, parse.AtLine(fmt.Errorf("%w (%v, not %v)", ErrMismatchedRatings, partRatingTypes, ratingTypes), i+2)
+		}
+	}
+
+	return ratingTypes, nil
+}
+
+// checkRatingTypes makes sure every condition in the rules checks one of the given rating types
+func checkRatingTypes(rules map[string]Rule, ratingTypes []PartRatingType) error {
+	for _, name := range sortedRuleNames(rules) {
+		for _, condition := range rules[name].Conditions {
+			if !slices.Contains(ratingTypes, condition.PartRatingType) {
+				return fmt.Errorf("%w: %s checks %q", ErrUnknownRatingType, name, condition.PartRatingType)
+			}
+		}
+	}
+
+	return nil
+}
+
+func parsePart(input string) (Part, error) {
+	partPattern := regexp.MustCompile(`^\{([a-z]+=\d+(?:,[a-z]+=\d+)*)\}$`)
+	captures, err := parse.Match(partPattern, input)
+	if err != nil {
+		return Part{}, fmt.Errorf("malformed part: %w", err)
+	}
+
+	ratings, err := parse.Capture(captures, 1, func(rawRatings string) ([]Rating, error) {
+		return parse.Split(rawRatings, ",", parseRating)
+	})
+	if err != nil {
+		return Part{}, fmt.Errorf("parse ratings: %w", err)
+	}
+
+	part := Part{Ratings: ratings}
+	for i, rating := range ratings {
+		if slices.Contains(part.RatingTypes()[:i], rating.Type) {
+			return Part{}, fmt.Errorf("%w %q", ErrDuplicateRatingKey, rating.Type)
+		}
+	}
+
+	return part, nil
+}
+
+func parseRating(rawRating string) (Rating, error) {
+	ratingType, rawValue, _ := strings.Cut(rawRating, "=")
+	value, err := strconv.Atoi(rawValue)
+	if err != nil {
+		return Rating{}, fmt.Errorf("invalid rating %q: %w", rawRating, err)
+	}
+
+	return Rating{Type: PartRatingType(ratingType), Value: value}, nil
+}
+
+func parseRules(input string) (map[string]Rule, error) {
+	inputLines := strings.Split(input, "\n")
+	rules := make(map[string]Rule, len(inputLines))
+	for i, rawRule := range inputLines {
+		ruleName, rule, err := parseRule(rawRule)
+		if err != nil {
+			return nil, parse.AtLine(fmt.Errorf("invalid rule: %w", err), i+1)
+		}
+
+		rules[ruleName] = rule
+	}
+
+	return rules, nil
+}
+
+func parseRule(rawRule string) (string, Rule, error) {
+	declarationsPattern := regexp.MustCompile(`^([a-z]+)\{((?:[a-z]+[<>]\d+:[a-zAR]+,)+)([a-zAR]+)\}$`)
+	captures, err := parse.Match(declarationsPattern, rawRule)
+	if err != nil {
+		return "", Rule{}, fmt.Errorf("malformed declarations: %w", err)
+	}
+
+	name := captures.Group(1)
+	fallbackDestination := captures.Group(3)
+
+	conditions, err := parse.Capture(captures, 2, parseRuleConditions)
+	if err != nil {
+		return "", Rule{}, fmt.Errorf("parse conditions: %w", err)
+	}
+
+	rule := Rule{
+		Conditions:          conditions,
+		FallbackDestination: fallbackDestination,
+	}
+
+	return name, rule, nil
+}
+
+func buildRuleFuncs(rules map[string]Rule) map[string]func(Part) (RuleOutcome, error) {
+	ruleFuncs := make(map[string]func(Part) (RuleOutcome, error), len(rules))
+	for ruleName, rule := range rules {
+		ruleFuncs[ruleName] = buildRuleFunc(rule)
+	}
+
+	return ruleFuncs
+}
+
+func buildRuleFunc(rule Rule) func(Part) (RuleOutcome, error) {
+	baseFunc := func(Part) (RuleOutcome, error) {
+		return RuleOutcome{Destination: rule.FallbackDestination}, nil
+	}
+
+	// We must store all of the destination functions, otherwise we will
+	// be binding to old names of functions when wrapping :(
+	destFuncs := []func(Part) (RuleOutcome, error){baseFunc}
+	destFunc := func(part Part) (RuleOutcome, error) {
+		return destFuncs[0](part)
+	}
+
+	for i := len(rule.Conditions) - 1; i >= 0; i-- {
+		condition := rule.Conditions[i]
+		lastFunc := destFuncs[len(destFuncs)-1]
+		ruleDestFunc := func(part Part) (RuleOutcome, error) {
+			value, ok := part.Rating(condition.PartRatingType)
+			if !ok {
+				return RuleOutcome{}, fmt.Errorf("%w %q", ErrMissingRating, condition.PartRatingType)
+			}
+
+			if condition.Operator.Compare(value, condition.Operand) {
+				return RuleOutcome{Destination: condition.SuccessDestination, Condition: &condition}, nil
+			} else {
+				return lastFunc(part)
+			}
+		}
+
+		destFuncs = append(destFuncs, ruleDestFunc)
+		destFunc = ruleDestFunc
+	}
+
+	return destFunc
+}
+
+func parseRuleConditions(rawConditions string) ([]RuleCondition, error) {
+	return parse.Split(strings.TrimRight(rawConditions, ","), ",", parseRuleCondition)
+}
+
+func parseRuleCondition(rawCondition string) (RuleCondition, error) {
+	conditionPattern := regexp.MustCompile(`^([a-z]+)([<>])(\d+):([a-zAR]+)$`)
+	captures, err := parse.Match(conditionPattern, rawCondition)
+	if err != nil {
+		return RuleCondition{}, fmt.Errorf("malformed condition %q: %w", rawCondition, err)
+	}
+
+	// The operator is definitely safe, because the pattern restricts it to
+	// a single char that is available in its type
+	ratingType := PartRatingType(captures.Group(1))
+	operator := ComparisonOperator(captures.Group(2)[0])
+	destination := captures.Group(4)
+
+	operand, err := captures.Int(3)
+	if err != nil {
+		return RuleCondition{}, fmt.Errorf("invalid operand in condition %q: %w", rawCondition, err)
+	}
+
+	return RuleCondition{
+		PartRatingType:     ratingType,
+		Operator:           operator,
+		Operand:            operand,
+		SuccessDestination: destination,
+	}, nil
+}
+
+func cloneMap[T comparable, U any, M ~map[T]U](m M) M {
+	cloned := make(M, len(m))
+	for key, value := range m {
+		cloned[key] = value
+	}
+
+	return cloned
+}
@@ -0,0 +1,69 @@
+package day19
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestSolvesWithOtherRatingsAndBounds(t *testing.T) {
+	// Parts are accepted if their width is over 5, or their depth is below 3
+	system, err := parseSystem("in{width>5:A,wd}\nwd{depth<3:A,R}\n\n{width=6,depth=9,height=1}\n{depth=1,width=2,height=4}\n{width=3,depth=3,height=2}")
+	if err != nil {
+		t.Fatalf("Failed to parse system: %s", err)
+	}
+
+	part1Answer, err := part1(system.rules, system.ratingTypes, system.parts)
+	if err != nil {
+		t.Fatalf("Failed to solve part 1: %s", err)
+	} else if part1Answer != 23 {
+		t.Fatalf("Got %d for part 1, not 23", part1Answer)
+	}
+
+	// With ratings from 1 to 10, 5 widths are over 5, and of the others, 2 depths are below 3
+	part2Answer, err := part2(system.rules, system.ratingTypes, NewRange(1, 10))
+	if err != nil {
+		t.Fatalf("Failed to solve part 2: %s", err)
+	} else if part2Answer != (5*10+5*2)*10 {
+		t.Fatalf("Got %d for part 2, not %d", part2Answer, (5*10+5*2)*10)
+	}
+}
+
+func TestPartsMustShareRatings(t *testing.T) {
+	_, err := parseSystem("in{x>5:A,R}\n\n{x=1,m=2}\n{x=1,a=2}")
+	if !errors.Is(err, ErrMismatchedRatings) {
+		t.Fatalf("Got error %v, not %v", err, ErrMismatchedRatings)
+	}
+}
+
+func TestRulesMustCheckRatingsThatPartsHave(t *testing.T) {
+	_, err := parseSystem("in{q>5:A,R}\n\n{x=1,m=2}")
+	if !errors.Is(err, ErrUnknownRatingType) {
+		t.Fatalf("Got error %v, not %v", err, ErrUnknownRatingType)
+	}
+}
+
+func TestOutOfRangeNumbersAreParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "rating",
+			input: "in{x>5:A,R}\n\n{x=12345678901234567890123}",
+		},
+		{
+			name:  "condition",
+			input: "in{x>12345678901234567890123:A,R}\n\n{x=1}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSystem(tt.input)
+			if !errors.Is(err, strconv.ErrRange) {
+				t.Fatalf("Got error %v, not %v", err, strconv.ErrRange)
+			}
+		})
+	}
+}
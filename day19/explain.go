@@ -0,0 +1,129 @@
+package day19
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PartTrace is the path a part took through the workflows
+type PartTrace struct {
+	Part     Part        `json:"part"`
+	Steps    []TraceStep `json:"steps"`
+	Accepted bool        `json:"accepted"`
+}
+
+// TraceStep is a single workflow a part was run through, and where it went next
+type TraceStep struct {
+	Workflow    string          `json:"workflow"`
+	Destination string          `json:"destination"`
+	Condition   *TraceCondition `json:"condition"`
+}
+
+// TraceCondition is the condition that sent a part to the next workflow, as it will be output. A nil condition
+// means that the workflow fell back.
+type TraceCondition struct {
+	Rating   string `json:"rating"`
+	Operator string `json:"operator"`
+	Operand  int    `json:"operand"`
+}
+
+var ErrInvalidFormat = errors.New("invalid output format")
+
+// explainCommand prints the path that every part takes through the workflows. The output is text unless
+// --format json is given.
+func explainCommand(system System, args []string, out io.Writer) error {
+	flagSet := flag.NewFlagSet("explain", flag.ContinueOnError)
+	format := flagSet.String("format", "text", "the format to print the traces in (text or json)")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("%w %q", ErrInvalidFormat, *format)
+	}
+
+	traces, err := explainParts(system.rules, system.parts)
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		// The operators would otherwise be escaped
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(traces); err != nil {
+			return fmt.Errorf("encode traces: %w", err)
+		}
+
+		return nil
+	}
+
+	for _, trace := range traces {
+		fmt.Fprintln(out, trace)
+	}
+
+	return nil
+}
+
+// explainParts traces the path each of the parts takes through the workflows
+func explainParts(rules map[string]Rule, parts []Part) ([]PartTrace, error) {
+	ruleFuncs := buildRuleFuncs(rules)
+	traces := make([]PartTrace, 0, len(parts))
+	for _, part := range parts {
+		trace := PartTrace{Part: part, Steps: []TraceStep{}}
+		accepted, err := walkPart(ruleFuncs, part, func(workflow string, outcome RuleOutcome) {
+			trace.Steps = append(trace.Steps, makeTraceStep(workflow, outcome))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not process part %v: %w", part, err)
+		}
+
+		trace.Accepted = accepted
+		traces = append(traces, trace)
+	}
+
+	return traces, nil
+}
+
+func makeTraceStep(workflow string, outcome RuleOutcome) TraceStep {
+	step := TraceStep{Workflow: workflow, Destination: outcome.Destination}
+	if outcome.Condition != nil {
+		step.Condition = &TraceCondition{
+			Rating:   string(outcome.Condition.PartRatingType),
+			Operator: string(outcome.Condition.Operator),
+			Operand:  outcome.Condition.Operand,
+		}
+	}
+
+	return step
+}
+
+// String describes the trace like the puzzle does (e.g. "{x=787,m=2655,a=1222,s=2876}: in -> qqz -> A"), with the
+// condition that sent the part on from each workflow
+func (trace PartTrace) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(trace.Part.String())
+	sb.WriteString(":")
+	for _, step := range trace.Steps {
+		fmt.Fprintf(&sb, " %s (%s) ->", step.Workflow, step.Condition)
+	}
+
+	if len(trace.Steps) > 0 {
+		fmt.Fprintf(&sb, " %s", trace.Steps[len(trace.Steps)-1].Destination)
+	}
+
+	return sb.String()
+}
+
+func (condition *TraceCondition) String() string {
+	if condition == nil {
+		return "fallback"
+	}
+
+	return fmt.Sprintf("%s%s%d", condition.Rating, condition.Operator, condition.Operand)
+}
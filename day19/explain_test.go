@@ -0,0 +1,27 @@
+package day19
+
+import "testing"
+
+func TestExplainDescribesEachStep(t *testing.T) {
+	rules, err := parseRules("in{s<1351:px,qqz}\npx{a<2006:qkq,m>2090:A,rfg}\nqqz{s>2770:qs,m<1801:hdj,R}\nqs{s>3448:A,lnx}\nlnx{m>1548:A,A}")
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %s", err)
+	}
+
+	part, err := parsePart("{x=787,m=2655,a=1222,s=2876}")
+	if err != nil {
+		t.Fatalf("Failed to parse part: %s", err)
+	}
+
+	traces, err := explainParts(rules, []Part{part})
+	if err != nil {
+		t.Fatalf("Failed to explain: %s", err)
+	}
+
+	expected := "{x=787,m=2655,a=1222,s=2876}: in (fallback) -> qqz (s>2770) -> qs (fallback) -> lnx (m>1548) -> A"
+	if len(traces) != 1 || traces[0].String() != expected {
+		t.Fatalf("Got %v, not %q", traces, expected)
+	} else if !traces[0].Accepted {
+		t.Fatalf("Part was not accepted")
+	}
+}
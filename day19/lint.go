@@ -0,0 +1,271 @@
+package day19
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+type LintIssueKind int
+
+const (
+	LintIssueCycle LintIssueKind = iota
+	LintIssueUndefinedDestination
+	LintIssueUnreachableWorkflow
+	LintIssueShadowedCondition
+)
+
+// LintIssue is a problem found with the workflows, which would either stop a part from being sorted, or means some
+// of the workflows do nothing
+type LintIssue struct {
+	Kind     LintIssueKind
+	Workflow string
+	Message  string
+}
+
+var ErrLintIssues = errors.New("workflows have issues")
+
+func (issue LintIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", issue.Workflow, issue.Kind, issue.Message)
+}
+
+func (kind LintIssueKind) String() string {
+	switch kind {
+	case LintIssueCycle:
+		return "cycle"
+	case LintIssueUndefinedDestination:
+		return "undefined destination"
+	case LintIssueUnreachableWorkflow:
+		return "unreachable workflow"
+	case LintIssueShadowedCondition:
+		return "shadowed condition"
+	default:
+		panic(fmt.Sprintf("invalid lint issue kind %d", kind))
+	}
+}
+
+// lintCommand prints every issue found with the workflows, and fails if there were any
+func lintCommand(system System, args []string, out io.Writer) error {
+	flagSet := flag.NewFlagSet("lint", flag.ContinueOnError)
+	bounds := DefaultRatingBounds
+	registerBoundsFlags(flagSet, &bounds)
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	issues := lintRules(system.rules, bounds)
+	for _, issue := range issues {
+		fmt.Fprintln(out, issue)
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("%w (found %d)", ErrLintIssues, len(issues))
+	}
+
+	return nil
+}
+
+// lintRules statically checks the workflows for problems, without needing any parts, for ratings within the given
+// bounds. Issues are sorted by the name of the workflow they were found in.
+func lintRules(rules map[string]Rule, bounds Range) []LintIssue {
+	issues := []LintIssue{}
+	if _, ok := rules[StartRuleName]; !ok {
+		issues = append(issues, LintIssue{
+			Kind:     LintIssueUndefinedDestination,
+			Workflow: StartRuleName,
+			Message:  "the starting workflow is not defined",
+		})
+	}
+
+	ruleNames := sortedRuleNames(rules)
+	for _, name := range ruleNames {
+		issues = append(issues, undefinedDestinationIssues(rules, name)...)
+		issues = append(issues, shadowedConditionIssues(rules, name, bounds)...)
+	}
+
+	for _, cycle := range findCycles(rules, bounds) {
+		issues = append(issues, LintIssue{
+			Kind:     LintIssueCycle,
+			Workflow: cycle[0],
+			Message:  fmt.Sprintf("parts can loop forever through %s", strings.Join(cycle, " -> ")),
+		})
+	}
+
+	reachable := reachableRules(rules, bounds)
+	for _, name := range ruleNames {
+		if _, ok := reachable[name]; !ok {
+			issues = append(issues, LintIssue{
+				Kind:     LintIssueUnreachableWorkflow,
+				Workflow: name,
+				Message:  fmt.Sprintf("no part can be sent here from %s", StartRuleName),
+			})
+		}
+	}
+
+	slices.SortStableFunc(issues, func(a, b LintIssue) int {
+		return strings.Compare(a.Workflow, b.Workflow)
+	})
+
+	return issues
+}
+
+func undefinedDestinationIssues(rules map[string]Rule, name string) []LintIssue {
+	issues := []LintIssue{}
+	for _, destination := range rules[name].Destinations() {
+		if _, ok := rules[destination]; !ok && !isTerminalDestination(destination) {
+			issues = append(issues, LintIssue{
+				Kind:     LintIssueUndefinedDestination,
+				Workflow: name,
+				Message:  fmt.Sprintf("sends parts to %s, which is not defined", destination),
+			})
+		}
+	}
+
+	return issues
+}
+
+func shadowedConditionIssues(rules map[string]Rule, name string, bounds Range) []LintIssue {
+	issues := []LintIssue{}
+	rule := rules[name]
+	for i, reachesCondition := range rule.ReachableConditions(bounds) {
+		if reachesCondition {
+			continue
+		}
+
+		message := "the fallback is never used, as the conditions before it match every part"
+		if i < len(rule.Conditions) {
+			message = fmt.Sprintf("condition %d (%s) can never match a part that gets to it", i+1, rule.Conditions[i])
+		}
+
+		issues = append(issues, LintIssue{
+			Kind:     LintIssueShadowedCondition,
+			Workflow: name,
+			Message:  message,
+		})
+	}
+
+	return issues
+}
+
+// ReachableConditions checks whether any part, with ratings in the given bounds, can make it to each of the rule's
+// conditions and then match it. The last element is for the fallback.
+func (rule Rule) ReachableConditions(bounds Range) []bool {
+	res := make([]bool, 0, len(rule.Conditions)+1)
+	// remainingBox holds the ratings of the parts that get past each condition. Ratings that no condition has checked
+	// yet can be anything within the bounds, so they are only added once they are narrowed. Once any rating has no
+	// values left, no part can get any further.
+	remainingBox := RatingBox{}
+	for _, condition := range rule.Conditions {
+		remainingRange, ok := remainingBox[condition.PartRatingType]
+		if !ok {
+			remainingRange = bounds
+		}
+
+		matchingRange, unmatchedRange := condition.SplitRange(remainingRange)
+		res = append(res, !bounds.Empty() && !remainingBox.Empty() && !matchingRange.Empty())
+
+		remainingBox[condition.PartRatingType] = unmatchedRange
+	}
+
+	return append(res, !bounds.Empty() && !remainingBox.Empty())
+}
+
+// effectiveDestinations gets the destinations that a part, with ratings in the given bounds, can actually be sent to
+// by the given rule
+func (rule Rule) effectiveDestinations(bounds Range) []string {
+	destinations := rule.Destinations()
+	res := []string{}
+	for i, reachable := range rule.ReachableConditions(bounds) {
+		if reachable {
+			res = append(res, destinations[i])
+		}
+	}
+
+	return res
+}
+
+// findCycles finds the loops in the workflows that a part could go around forever. Each cycle is given as the
+// workflows in it, with the first repeated at the end.
+func findCycles(rules map[string]Rule, bounds Range) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	cycles := [][]string{}
+	states := map[string]int{}
+	path := []string{}
+	var visit func(string)
+	visit = func(name string) {
+		rule, ok := rules[name]
+		if !ok {
+			return
+		}
+
+		states[name] = visiting
+		path = append(path, name)
+		for _, destination := range rule.effectiveDestinations(bounds) {
+			switch states[destination] {
+			case unvisited:
+				visit(destination)
+			case visiting:
+				cycleStart := slices.Index(path, destination)
+				cycle := slices.Clone(path[cycleStart:])
+				cycles = append(cycles, append(cycle, destination))
+			}
+		}
+
+		path = path[:len(path)-1]
+		states[name] = visited
+	}
+
+	// Walk from the start first, so cycles are described from the direction that parts will go around them
+	visit(StartRuleName)
+	for _, name := range sortedRuleNames(rules) {
+		if states[name] == unvisited {
+			visit(name)
+		}
+	}
+
+	return cycles
+}
+
+// reachableRules finds all of the rules a part could be sent to from the starting rule
+func reachableRules(rules map[string]Rule, bounds Range) map[string]struct{} {
+	reachable := map[string]struct{}{}
+	toVisit := []string{StartRuleName}
+	for len(toVisit) > 0 {
+		visiting := toVisit[0]
+		toVisit = toVisit[1:]
+		rule, ok := rules[visiting]
+		if !ok {
+			continue
+		} else if _, ok := reachable[visiting]; ok {
+			continue
+		}
+
+		reachable[visiting] = struct{}{}
+		toVisit = append(toVisit, rule.effectiveDestinations(bounds)...)
+	}
+
+	return reachable
+}
+
+func sortedRuleNames(rules map[string]Rule) []string {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func isTerminalDestination(destination string) bool {
+	return destination == AcceptedDestination || destination == RejectedDestination
+}
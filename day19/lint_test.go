@@ -0,0 +1,72 @@
+package day19
+
+import (
+	"slices"
+	"testing"
+)
+
+func lintIssueKinds(t *testing.T, rawRules string) []LintIssueKind {
+	t.Helper()
+	rules, err := parseRules(rawRules)
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %s", err)
+	}
+
+	kinds := []LintIssueKind{}
+	for _, issue := range lintRules(rules, DefaultRatingBounds) {
+		kinds = append(kinds, issue.Kind)
+	}
+
+	return kinds
+}
+
+func TestLintFindsNoIssuesWithValidWorkflows(t *testing.T) {
+	kinds := lintIssueKinds(t, "in{x<100:ab,R}\nab{m>10:A,R}")
+	if len(kinds) != 0 {
+		t.Fatalf("Got issues %v, not none", kinds)
+	}
+}
+
+func TestLintFindsCycle(t *testing.T) {
+	kinds := lintIssueKinds(t, "in{x<100:ab,R}\nab{m>10:in,A}")
+	if !slices.Equal(kinds, []LintIssueKind{LintIssueCycle}) {
+		t.Fatalf("Got issues %v, not a cycle", kinds)
+	}
+}
+
+func TestLintFindsUndefinedDestination(t *testing.T) {
+	kinds := lintIssueKinds(t, "in{x<100:ab,A}")
+	if !slices.Equal(kinds, []LintIssueKind{LintIssueUndefinedDestination}) {
+		t.Fatalf("Got issues %v, not an undefined destination", kinds)
+	}
+}
+
+func TestLintFindsShadowedConditionAndTheWorkflowOnlyItReaches(t *testing.T) {
+	kinds := lintIssueKinds(t, "in{x<100:A,x<50:ab,R}\nab{m>10:A,R}")
+	expected := []LintIssueKind{LintIssueUnreachableWorkflow, LintIssueShadowedCondition}
+	if !slices.Equal(kinds, expected) {
+		t.Fatalf("Got issues %v, not %v", kinds, expected)
+	}
+}
+
+func TestConditionsAfterOneMatchingEveryPartAreShadowed(t *testing.T) {
+	rules, err := parseRules("in{x>0:A,m<100:ab,R}\nab{a>10:A,R}")
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %s", err)
+	}
+
+	reachable := rules[StartRuleName].ReachableConditions(DefaultRatingBounds)
+	if !slices.Equal(reachable, []bool{true, false, false}) {
+		t.Fatalf("Got %v, not [true false false]", reachable)
+	}
+
+	kinds := []LintIssueKind{}
+	for _, issue := range lintRules(rules, DefaultRatingBounds) {
+		kinds = append(kinds, issue.Kind)
+	}
+
+	expected := []LintIssueKind{LintIssueUnreachableWorkflow, LintIssueShadowedCondition, LintIssueShadowedCondition}
+	if !slices.Equal(kinds, expected) {
+		t.Fatalf("Got issues %v, not %v", kinds, expected)
+	}
+}
@@ -0,0 +1,138 @@
+package day2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+type CubeCounts = map[string]int
+
+type Game struct {
+	id     int
+	rounds []CubeCounts
+}
+
+func init() {
+	aoc.Register(2, aoc.Solution[[]Game, int]{
+		Parse: func(input string) ([]Game, error) {
+			return parse.Lines(input, parseGame)
+		},
+		Part1: part1,
+		Part2: part2,
+	})
+}
+
+func part1(_ context.Context, games []Game) (int, error) {
+	invalidIDTotal := 0
+	for _, game := range games {
+		if isGameValid(game) {
+			invalidIDTotal += game.id
+		}
+	}
+
+	return invalidIDTotal, nil
+}
+
+func part2(_ context.Context, games []Game) (int, error) {
+	totalPower := 0
+	for _, game := range games {
+		minPossibleCubes := maxCubesByColor(game)
+		totalPower += cubePower(minPossibleCubes)
+	}
+
+	return totalPower, nil
+}
+
+// isGameValid will check if the given game is valid by the number of cubes in the bag
+func isGameValid(game Game) bool {
+	cubeCounts := map[string]int{
+		"red":   12,
+		"green": 13,
+		"blue":  14,
+	}
+
+	for _, round := range game.rounds {
+		for color, count := range round {
+			if count > cubeCounts[color] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// maxCubesByColor will get the maximum quantity of cubes for each color in the game's rounds
+func maxCubesByColor(game Game) CubeCounts {
+	maxCounts := CubeCounts{}
+	for _, round := range game.rounds {
+		for color, count := range round {
+			currentMax := maxCounts[color]
+			if count > currentMax {
+				maxCounts[color] = count
+			}
+		}
+	}
+
+	return maxCounts
+}
+
+// cubePower calculates the "power" of the cubes selected in a round
+func cubePower(cubes CubeCounts) int {
+	return cubes["red"] * cubes["green"] * cubes["blue"]
+}
+
+func parseGame(line string) (Game, error) {
+	gamePattern := regexp.MustCompile(`^Game (\d+): (.*)$`)
+	captures, err := parse.Match(gamePattern, line)
+	if err != nil {
+		return Game{}, fmt.Errorf("malformed game line: %w", err)
+	}
+
+	gameID, err := captures.Int(1)
+	if err != nil {
+		return Game{}, fmt.Errorf("invalid game id %s: %w", captures.Group(1), err)
+	}
+
+	rounds, err := parse.Capture(captures, 2, parseRounds)
+	if err != nil {
+		return Game{}, fmt.Errorf("invalid rounds: %w", err)
+	}
+
+	return Game{
+		id:     gameID,
+		rounds: rounds,
+	}, nil
+}
+
+func parseRounds(roundsSpec string) ([]CubeCounts, error) {
+	return parse.Split(roundsSpec, ";", parseRound)
+}
+
+func parseRound(round string) (CubeCounts, error) {
+	roundPattern := regexp.MustCompile(`(\d+) (\w+)`)
+	matches := roundPattern.FindAllStringSubmatch(round, -1)
+	if matches == nil {
+		return nil, errors.New("malformed round spec")
+	}
+
+	cubes := CubeCounts{}
+	for _, match := range matches {
+		color := match[2]
+		rawCubeCount := match[1]
+		count, err := strconv.Atoi(rawCubeCount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cube count %s: %w", rawCubeCount, err)
+		}
+
+		cubes[color] = count
+	}
+
+	return cubes, nil
+}
@@ -1,16 +1,28 @@
-package main
+package day20
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"os"
 	"regexp"
 	"slices"
 	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+const (
+	BroadcasterName = "broadcaster"
+	// OutputName is the name of the module that part 2 must send a low pulse to
+	OutputName = "rx"
 )
 
-const BroadcasterName = "broadcaster"
+var (
+	ErrNoBroadcaster       = errors.New("no broadcaster module")
+	ErrUnsupportedOutput   = errors.New("output must be fed by exactly one conjunction")
+	ErrUnalignedOutputFeed = errors.New("input to the output's conjunction does not send high pulses on a fixed cycle")
+)
 
 type WorkQueue []PendingPulse
 
@@ -71,6 +83,8 @@ type ModuleShim struct {
 }
 
 type PendingPulse struct {
+	from      string
+	to        string
 	pulse     Pulse
 	sendPulse func()
 }
@@ -132,6 +146,8 @@ func (handler ModuleShim) HandlePulse(source PulseHandler, pulse Pulse) {
 	}
 
 	pendingPulse := PendingPulse{
+		from:      source.ID(),
+		to:        handler.name,
 		pulse:     pulse,
 		sendPulse: pulseFunc,
 	}
@@ -209,82 +225,193 @@ func (queue *WorkQueue) Pop() PendingPulse {
 	return front
 }
 
-func main() {
-	if len(os.Args) != 2 && len(os.Args) != 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s inputfile\n", os.Args[0])
-		os.Exit(1)
-	}
+func init() {
+	aoc.Register(20, aoc.Solution[[]ParsedModule, int]{
+		Parse: func(input string) ([]ParsedModule, error) {
+			return parse.Lines(input, parseModule)
+		},
+		// Both parts change the state of the modules, so they must be built separately for each part
+		Part1: func(_ context.Context, parsedModules []ParsedModule) (int, error) {
+			workQueue := make(WorkQueue, 0)
+			modules := buildModules(parsedModules, &workQueue)
+
+			return part1(modules, &workQueue)
+		},
+		Part2: func(ctx context.Context, parsedModules []ParsedModule) (int, error) {
+			workQueue := make(WorkQueue, 0)
+			modules := buildModules(parsedModules, &workQueue)
+
+			return part2(ctx, parsedModules, modules, &workQueue)
+		},
+	})
+}
 
-	inputFilename := os.Args[1]
-	inputFile, err := os.Open(inputFilename)
+func part1(modules map[string]PulseHandler, workQueue *WorkQueue) (int, error) {
+	button, err := makeButton(modules, workQueue)
 	if err != nil {
-		panic(fmt.Sprintf("could not open input file: %s", err))
+		return 0, err
 	}
 
-	defer inputFile.Close()
+	pulseCounts := map[Pulse]int{}
+	for i := 0; i < 1000; i++ {
+		pressButton(button, workQueue, func(pulse PendingPulse) {
+			pulseCounts[pulse.pulse]++
+		})
+	}
 
-	inputBytes, err := io.ReadAll(inputFile)
-	if err != nil {
-		panic(fmt.Sprintf("could not read input file: %s", err))
+	total := 1
+	for _, n := range pulseCounts {
+		total *= n
 	}
 
-	input := strings.TrimSpace(string(inputBytes))
-	inputLines := strings.Split(input, "\n")
+	return total, nil
+}
+
+// part2 finds the number of presses needed for the output to get a low pulse. The output is fed by a single
+// conjunction, so we find how often each of that conjunction's inputs sends it a high pulse, and find when they
+// all line up. This assumes (as the puzzle inputs do) that each input does so on a fixed cycle. If an input never
+// sends a high pulse, this will press the button until the context is done.
+func part2(
+	ctx context.Context,
+	parsedModules []ParsedModule,
+	modules map[string]PulseHandler,
+	workQueue *WorkQueue,
+) (int, error) {
+	outputFeeds := findParentModules(parsedModules, OutputName)
+	if len(outputFeeds) != 1 {
+		return 0, fmt.Errorf("%w (found %d modules feeding it)", ErrUnsupportedOutput, len(outputFeeds))
+	}
+
+	feedIdx := slices.IndexFunc(parsedModules, func(module ParsedModule) bool {
+		return module.Name == outputFeeds[0]
+	})
+	if parsedModules[feedIdx].Kind != ParsedModuleConjunction {
+		return 0, fmt.Errorf("%w (%s is not a conjunction)", ErrUnsupportedOutput, outputFeeds[0])
+	}
 
-	workQueue := make(WorkQueue, 0)
-	modules, err := buildModulesFromInput(inputLines, &workQueue)
+	button, err := makeButton(modules, workQueue)
 	if err != nil {
-		panic(fmt.Sprintf("could not parse modules: %s", err))
+		return 0, err
+	}
+
+	feed := parsedModules[feedIdx].Name
+	feedInputs := findParentModules(parsedModules, feed)
+	if len(feedInputs) == 0 {
+		// With no inputs, the conjunction will never send anything
+		return 0, fmt.Errorf("%w (%s has no inputs)", ErrUnsupportedOutput, feed)
+	}
+
+	// We need to see two high pulses from each input, so that we know how long its cycle is
+	highPulsePresses := make(map[string][]int, len(feedInputs))
+	allInputsSeenTwice := func() bool {
+		for _, input := range feedInputs {
+			if len(highPulsePresses[input]) < 2 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for presses := 1; !allInputsSeenTwice(); presses++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		pressButton(button, workQueue, func(pulse PendingPulse) {
+			if pulse.to != feed || pulse.pulse != PulseHigh {
+				return
+			}
+
+			// An input may send several high pulses in the same press
+			seenAt := highPulsePresses[pulse.from]
+			if len(seenAt) == 0 || seenAt[len(seenAt)-1] != presses {
+				highPulsePresses[pulse.from] = append(seenAt, presses)
+			}
+		})
+	}
+
+	periods := make([]int, 0, len(feedInputs))
+	for _, input := range feedInputs {
+		seenAt := highPulsePresses[input]
+		period := seenAt[1] - seenAt[0]
+		// The LCM only gives us the answer if every cycle starts from the first press
+		if seenAt[0] != period {
+			return 0, fmt.Errorf("%w (%s first sent one after %d presses, but repeats every %d)", ErrUnalignedOutputFeed, input, seenAt[0], period)
+		}
+
+		periods = append(periods, period)
 	}
 
-	fmt.Printf("Part 1: %d\n", part1(modules, &workQueue))
+	return sliceLCM(periods), nil
 }
 
-func part1(modules map[string]PulseHandler, workQueue *WorkQueue) int {
+// makeButton makes a module that sends a pulse to the broadcaster when pressed
+func makeButton(modules map[string]PulseHandler, workQueue *WorkQueue) (PulseHandler, error) {
+	if _, ok := modules[BroadcasterName]; !ok {
+		return nil, ErrNoBroadcaster
+	}
+
 	button := NewBroadcaster("button", []PulseHandler{
-		NewShimmedModule("broadcaster", modules, workQueue),
+		NewShimmedModule(BroadcasterName, modules, workQueue),
 	})
 
-	pulseCounts := map[Pulse]int{}
-	for i := 0; i < 1000; i++ {
-		button.HandlePulse(nil, PulseLow)
+	return button, nil
+}
 
-		for len(*workQueue) > 0 {
-			work := workQueue.Pop()
-			pulseCounts[work.pulse]++
-			work.sendPulse()
-		}
-		fmt.Println()
+// pressButton presses the button once, and sends every pulse that results until the system settles. Each pulse is
+// given to observe just before it is sent.
+func pressButton(button PulseHandler, workQueue *WorkQueue, observe func(PendingPulse)) {
+	button.HandlePulse(nil, PulseLow)
+
+	for len(*workQueue) > 0 {
+		work := workQueue.Pop()
+		observe(work)
+		work.sendPulse()
 	}
+}
 
-	fmt.Println(pulseCounts)
-	total := 1
-	for _, n := range pulseCounts {
-		total *= n
+// sliceLCM finds the LCM of the numbers in the given slice. Panics if the slice is of length zero
+func sliceLCM(nums []int) int {
+	if len(nums) == 0 {
+		panic("cannot find lcm of zero numbers")
+	}
+
+	result := nums[0]
+	for _, n := range nums[1:] {
+		result = lcm(result, n)
 	}
 
-	return total
+	return result
 }
 
-func buildModulesFromInput(inputLines []string, pulseQueue *WorkQueue) (map[string]PulseHandler, error) {
-	parsedModules, err := tryParse(inputLines, parseModule)
-	if err != nil {
-		return nil, fmt.Errorf("parse modules: %w", err)
+func lcm(a, b int) int {
+	return b * (a / gcd(a, b))
+}
+
+func gcd(a, b int) int {
+	// https://en.wikipedia.org/wiki/Euclidean_algorithm
+	factor := a
+	rem := b
+	for rem != 0 {
+		oldRem := rem
+		rem = factor % rem
+		factor = oldRem
 	}
 
-	return buildModules(parsedModules, pulseQueue), nil
+	return factor
 }
 
 func parseModule(inputLine string) (ParsedModule, error) {
 	modulePattern := regexp.MustCompile(`^([%&]?)([a-z]+) -> ((?:[a-z]+(?:, )?)+)$`)
-	matches := modulePattern.FindStringSubmatch(inputLine)
-	if matches == nil {
-		return ParsedModule{}, errors.New("malformed module")
+	captures, err := parse.Match(modulePattern, inputLine)
+	if err != nil {
+		return ParsedModule{}, fmt.Errorf("malformed module: %w", err)
 	}
 
-	name := matches[2]
-	children := strings.Split(matches[3], ", ")
-	moduleKind, err := moduleKindFromPrefix(matches[1])
+	name := captures.Group(2)
+	children := strings.Split(captures.Group(3), ", ")
+	moduleKind, err := parse.Capture(captures, 1, moduleKindFromPrefix)
 	if err != nil {
 		return ParsedModule{}, fmt.Errorf("module kind: %w", err)
 	} else if moduleKind == ParsedModuleBroadcaster && name != BroadcasterName {
@@ -354,22 +481,6 @@ func moduleKindFromPrefix(prefix string) (ParsedModuleKind, error) {
 
 func broadcastPulse(source PulseHandler, recipients []PulseHandler, pulse Pulse) {
 	for _, child := range recipients {
-		fmt.Printf("%s --%s--> %s\n", source.ID(), pulse, child.ID())
-
 		child.HandlePulse(source, pulse)
 	}
 }
-
-func tryParse[T any](items []string, parse func(string) (T, error)) ([]T, error) {
-	res := make([]T, 0, len(items))
-	for i, item := range items {
-		parsed, err := parse(item)
-		if err != nil {
-			return nil, fmt.Errorf("invalid item #%d: %w", i+1, err)
-		}
-
-		res = append(res, parsed)
-	}
-
-	return res, nil
-}
@@ -1,8 +1,14 @@
-package main
+package day20
 
 import (
+	"context"
+	"errors"
 	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/ollien/advent-of-code-2023/parse"
 )
 
 type FakePulseHandler struct {
@@ -112,3 +118,28 @@ func TestConjunctionSendsHighPulseOnceAllSourcesHaveEmitted(t *testing.T) {
 		t.Fatalf("Got %d pulses, not high x2 + low x4", len(receiver.pulses))
 	}
 }
+
+func TestPart2GivesUpWhenAnInputNeverSendsAHighPulse(t *testing.T) {
+	// f only ever gets high pulses from c, so it never sends anything to fd
+	input := strings.Join([]string{
+		"broadcaster -> c",
+		"&c -> f",
+		"%f -> fd",
+		"&fd -> rx",
+	}, "\n")
+
+	parsedModules, err := parse.Lines(input, parseModule)
+	if err != nil {
+		t.Fatalf("Failed to parse: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	workQueue := make(WorkQueue, 0)
+	modules := buildModules(parsedModules, &workQueue)
+	_, err = part2(ctx, parsedModules, modules, &workQueue)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Got error %v, not %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,244 @@
+package day21
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/grid"
+)
+
+type Tile rune
+
+const (
+	TileTypeGarden Tile = '.'
+	TileTypeRock   Tile = '#'
+)
+
+type Garden struct {
+	tiles grid.Grid[Tile]
+	start grid.Coordinate
+}
+
+// ErrNotSquare is returned when trying to extrapolate part 2 for a grid that is not square. The number of plots only
+// grows in a regular pattern if the grid repeats at the same rate in every direction.
+var ErrNotSquare = errors.New("grid must be square to extrapolate")
+
+func init() {
+	part1Steps := 64
+	part2Steps := 26501365
+	exact := false
+	aoc.Register(21, aoc.Solution[Garden, int]{
+		Parse: func(input string) (Garden, error) {
+			tiles, start, err := parseGrid(strings.Split(input, "\n"))
+			if err != nil {
+				return Garden{}, err
+			}
+
+			return Garden{tiles: tiles, start: start}, nil
+		},
+		Flags: func(flagSet *flag.FlagSet) {
+			flagSet.IntVar(&part1Steps, "part1steps", 64, "the number of steps the elf takes in part 1")
+			flagSet.IntVar(&part2Steps, "part2steps", 26501365, "the number of steps the elf takes in part 2")
+			flagSet.BoolVar(&exact, "exact", false, "walk every step of part 2 on the infinite grid, rather than extrapolating")
+		},
+		Part1: func(ctx context.Context, garden Garden) (int, error) {
+			return part1(ctx, garden.tiles, garden.start, part1Steps)
+		},
+		Part2: func(ctx context.Context, garden Garden) (int, error) {
+			if exact {
+				counts, err := countReachableOnInfiniteGrid(ctx, garden.tiles, garden.start, []int{part2Steps})
+				if err != nil {
+					return 0, err
+				}
+
+				return counts[0], nil
+			}
+
+			return part2(ctx, garden.tiles, garden.start, part2Steps)
+		},
+	})
+}
+
+func part1(ctx context.Context, tiles grid.Grid[Tile], start grid.Coordinate, steps int) (int, error) {
+	cursors := []grid.Coordinate{start}
+	lastCount := 0
+	for i := 0; i < steps; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		nextCursors := []grid.Coordinate{}
+		visited := map[grid.Coordinate]struct{}{}
+		for _, cursor := range cursors {
+			for _, neighbor := range cursor.CardinalNeighbors() {
+				// Anything beyond the edge of the map is treated as garden
+				if tile, ok := tiles.Lookup(neighbor); ok && tile == TileTypeRock {
+					continue
+				} else if _, ok := visited[neighbor]; ok {
+					continue
+				}
+
+				visited[neighbor] = struct{}{}
+				nextCursors = append(nextCursors, neighbor)
+			}
+		}
+
+		cursors = nextCursors
+		lastCount = len(visited)
+	}
+
+	return lastCount, nil
+}
+
+// part2 finds the number of plots reachable in the given number of steps on the infinite grid. Once the elf has
+// reached the edges of the grid, every time it walks the width of the grid it reaches another "ring" of copies of
+// the grid, so the number of plots grows quadratically with the number of copies walked through. We can walk three
+// of them and extrapolate the rest of the way.
+func part2(ctx context.Context, tiles grid.Grid[Tile], start grid.Coordinate, steps int) (int, error) {
+	if tiles.Width() != tiles.Height() {
+		return 0, fmt.Errorf("%w (got %dx%d)", ErrNotSquare, tiles.Width(), tiles.Height())
+	}
+
+	size := tiles.Width()
+	// Each sample must be the same distance into a copy of the grid as the number of steps we want, and the first
+	// must be far enough out to have reached every edge of the original
+	edgeDistance := max(start.Row, start.Col, tiles.Height()-1-start.Row, tiles.Width()-1-start.Col)
+	firstSample := steps % size
+	for firstSample < edgeDistance {
+		firstSample += size
+	}
+
+	sampleSteps := []int{firstSample, firstSample + size, firstSample + 2*size}
+	if steps <= sampleSteps[len(sampleSteps)-1] {
+		// It's no more work to just walk there
+		counts, err := countReachableOnInfiniteGrid(ctx, tiles, start, []int{steps})
+		if err != nil {
+			return 0, err
+		}
+
+		return counts[0], nil
+	}
+
+	counts, err := countReachableOnInfiniteGrid(ctx, tiles, start, sampleSteps)
+	if err != nil {
+		return 0, err
+	}
+
+	x := [3]float64{}
+	y := [3]float64{}
+	for i := range sampleSteps {
+		x[i] = float64(sampleSteps[i])
+		y[i] = float64(counts[i])
+	}
+
+	// The fit is only approximate in floating point, but the answer is always a whole number of plots
+	return int(math.Round(fitQuadratic(x, y, float64(steps)))), nil
+}
+
+// countReachableOnInfiniteGrid finds the number of plots that can be reached in exactly each of the given numbers
+// of steps, where the grid repeats infinitely in every direction. The step counts must be in ascending order.
+func countReachableOnInfiniteGrid(
+	ctx context.Context,
+	tiles grid.Grid[Tile],
+	start grid.Coordinate,
+	steps []int,
+) ([]int, error) {
+	counts := make([]int, 0, len(steps))
+	cursors := []grid.Coordinate{start}
+	for i := 0; len(counts) < len(steps); i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Any number of steps that we want may be repeated, so record all of them
+		for len(counts) < len(steps) && steps[len(counts)] == i {
+			counts = append(counts, len(cursors))
+		}
+
+		nextCursors := []grid.Coordinate{}
+		visited := map[grid.Coordinate]struct{}{}
+		for _, cursor := range cursors {
+			for _, neighbor := range cursor.CardinalNeighbors() {
+				if tiles.AtWrapped(neighbor) == TileTypeRock {
+					continue
+				} else if _, ok := visited[neighbor]; ok {
+					continue
+				}
+
+				visited[neighbor] = struct{}{}
+				nextCursors = append(nextCursors, neighbor)
+			}
+		}
+
+		cursors = nextCursors
+	}
+
+	return counts, nil
+}
+
+func fitQuadratic(x [3]float64, y [3]float64, desired float64) float64 {
+	a0 := y[0]
+	a1 := (y[1] - y[0]) / (x[1] - x[0])
+
+	a2Numerator := (y[2]-y[1])/(x[2]-x[1]) - a1
+	a2Denominator := x[2] - x[0]
+	a2 := a2Numerator / a2Denominator
+
+	// https://pythonnumericalmethods.berkeley.edu/notebooks/chapter17.05-Newtons-Polynomial-Interpolation.html
+	return a2*(desired-x[1])*(desired-x[0]) + a1*(desired-x[0]) + a0
+}
+
+// not used, left for debugging
+func printGrid(tiles grid.Grid[Tile], cursors []grid.Coordinate) {
+	scale := 3
+	for i := -tiles.Height() * scale; i < tiles.Height()*scale; i++ {
+		for j := -tiles.Width() * scale; j < tiles.Width()*scale; j++ {
+			p := grid.Coordinate{Row: i, Col: j}
+			if slices.Index(cursors, p) != -1 {
+				fmt.Printf("\033[0;31mO\033[0m")
+			} else {
+				fmt.Printf("%c", tiles.AtWrapped(p))
+			}
+
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+}
+
+func parseGrid(inputLines []string) (grid.Grid[Tile], grid.Coordinate, error) {
+	start := grid.Coordinate{}
+	startFound := false
+	for row, line := range inputLines {
+		if col := strings.IndexRune(line, 'S'); col != -1 {
+			startFound = true
+			start = grid.Coordinate{Row: row, Col: len([]rune(line[:col]))}
+		}
+	}
+
+	if !startFound {
+		return grid.Grid[Tile]{}, grid.Coordinate{}, errors.New("no start tile found")
+	}
+
+	tiles, err := grid.Parse(inputLines, func(char rune) (Tile, error) {
+		switch char {
+		case 'S':
+			return TileTypeGarden, nil
+		case '.', '#':
+			return Tile(char), nil
+		default:
+			return TileTypeGarden, fmt.Errorf("invalid tile char %c", char)
+		}
+	})
+	if err != nil {
+		return grid.Grid[Tile]{}, grid.Coordinate{}, err
+	}
+
+	return tiles, start, nil
+}
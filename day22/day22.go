@@ -1,15 +1,14 @@
-package main
+package day22
 
 import (
 	"cmp"
-	"errors"
+	"context"
 	"fmt"
-	"io"
-	"os"
 	"regexp"
 	"slices"
-	"strconv"
-	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
 )
 
 type Coordinate struct {
@@ -58,73 +57,64 @@ func (b Brick) LowestPoint() Coordinate {
 	return slices.MinFunc(b, minZFunc)
 }
 
-func main() {
-	if len(os.Args) != 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s inputfile\n", os.Args[0])
-		os.Exit(1)
-	}
+func init() {
+	aoc.Register(22, aoc.Solution[[]Brick, int]{
+		Parse: func(input string) ([]Brick, error) {
+			return parseBricks(input)
+		},
+		Part1: part1,
+		Part2: part2,
+	})
+}
 
-	inputFilename := os.Args[1]
-	inputFile, err := os.Open(inputFilename)
+func part1(ctx context.Context, inputBricks []Brick) (int, error) {
+	slammedBricks, err := settleBricks(ctx, inputBricks)
 	if err != nil {
-		panic(fmt.Sprintf("could not open input file: %s", err))
+		return 0, fmt.Errorf("settle bricks: %w", err)
 	}
 
-	defer inputFile.Close()
-
-	inputBytes, err := io.ReadAll(inputFile)
-	if err != nil {
-		panic(fmt.Sprintf("could not read input file: %s", err))
-	}
+	removable := removableBricks(slammedBricks)
 
-	input := strings.TrimSpace(string(inputBytes))
-	inputLines := strings.Split(input, "\n")
-	bricks, err := parseBricks(inputLines)
-	if err != nil {
-		panic(fmt.Sprintf("invalid input: %s", err))
-	}
+	return len(removable), nil
+}
 
+func part2(ctx context.Context, inputBricks []Brick) (int, error) {
+	slammedBricks, err := settleBricks(ctx, inputBricks)
 	if err != nil {
-		panic(fmt.Sprintf("failed to parse input: %s", err))
+		return 0, fmt.Errorf("settle bricks: %w", err)
 	}
 
-	fmt.Printf("Part 1: %d\n", part1(bricks))
-	fmt.Printf("Part 2: %d\n", part2(bricks))
-}
-
-func part1(inputBricks []Brick) int {
-	slammedBricks := settleBricks(inputBricks)
-	removable := removableBricks(slammedBricks)
-
-	return len(removable)
-}
-
-func part2(inputBricks []Brick) int {
-	slammedBricks := settleBricks(inputBricks)
 	total := 0
 	for i := range slammedBricks {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
 		total += numBricksFallingByRemoval(slammedBricks, i)
 	}
 
-	return total
+	return total, nil
 }
 
-func settleBricks(bricks []Brick) []Brick {
+func settleBricks(ctx context.Context, bricks []Brick) ([]Brick, error) {
 	sorted := slices.Clone(bricks)
 	sortByHeight(sorted)
 
 	slammedBricks := slices.Clone(sorted)
 	for i := range sorted {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		brick, err := moveBrickDown(slammedBricks, i)
 		if err != nil {
-			// can't happen with our bounds
-			panic(err)
+			return nil, fmt.Errorf("move brick %d: %w", i, err)
 		}
 
 		slammedBricks[i] = brick
 	}
 
-	return slammedBricks
+	return slammedBricks, nil
 }
 
 func moveBrickDown(bricks []Brick, brickIdx int) (Brick, error) {
@@ -250,10 +240,10 @@ func occupiedPositions(bricks []Brick) map[Coordinate]int {
 	return occupied
 }
 
-func parseBricks(inputLines []string) ([]Brick, error) {
-	bricks, err := tryParse(inputLines, parseBrick)
+func parseBricks(input string) ([]Brick, error) {
+	bricks, err := parse.Lines(input, parseBrick)
 	if err != nil {
-		return nil, fmt.Errorf("parse brick: %s", err)
+		return nil, fmt.Errorf("parse brick: %w", err)
 	}
 
 	positions := map[Coordinate]struct{}{}
@@ -272,23 +262,22 @@ func parseBricks(inputLines []string) ([]Brick, error) {
 
 func parseBrick(line string) (Brick, error) {
 	pattern := regexp.MustCompile(`^(\d+),(\d+),(\d+)~(\d+),(\d+),(\d+)$`)
-	matches := pattern.FindStringSubmatch(line)
-	if matches == nil {
-		return nil, errors.New("malformed brick spec")
-	}
-
-	coordSlice1, err := tryParse([]string{matches[1], matches[2], matches[3]}, strconv.Atoi)
+	captures, err := parse.Match(pattern, line)
 	if err != nil {
-		// Can't happen, by the pattern
-		panic(fmt.Sprintf("could not convert coordinate to integers: %s", err))
+		return nil, fmt.Errorf("malformed brick spec: %w", err)
 	}
 
-	coordSlice2, err := tryParse([]string{matches[4], matches[5], matches[6]}, strconv.Atoi)
-	if err != nil {
-		// Can't happen, by the pattern
-		panic(fmt.Sprintf("could not convert coordinate to integers: %s", err))
+	coords := [6]int{}
+	for i := range coords {
+		coords[i], err = captures.Int(i + 1)
+		if err != nil {
+			return nil, fmt.Errorf("invalid coordinate %s: %w", captures.Group(i+1), err)
+		}
 	}
 
+	coordSlice1 := coords[:3]
+	coordSlice2 := coords[3:]
+
 	numDifferent := countDifferent(coordSlice1, coordSlice2)
 	if numDifferent > 1 {
 		return nil, fmt.Errorf("only one axis may differ in coordinates, found %d", numDifferent)
@@ -321,20 +310,6 @@ func countDifferent[T comparable, S ~[]T](s1, s2 S) int {
 	return count
 }
 
-func tryParse[T any](items []string, parse func(string) (T, error)) ([]T, error) {
-	res := make([]T, 0, len(items))
-	for i, item := range items {
-		parsed, err := parse(item)
-		if err != nil {
-			return nil, fmt.Errorf("invalid item #%d: %w", i+1, err)
-		}
-
-		res = append(res, parsed)
-	}
-
-	return res, nil
-}
-
 func mapKeysEqual[T comparable, U any](m1, m2 map[T]U) bool {
 	if len(m1) != len(m2) {
 		return false
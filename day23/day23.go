@@ -0,0 +1,262 @@
+// This code is horrible and repetitive, I'm sorry
+
+package day23
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/grid"
+)
+
+type Tile rune
+
+const (
+	TileEmpty Tile = '.'
+	TileWall  Tile = '#'
+	TileRight Tile = '>'
+	TileLeft  Tile = '<'
+	TileUp    Tile = '^'
+	TileDown  Tile = 'v'
+)
+
+type GraphNode struct {
+	Position grid.Coordinate
+	Weight   int
+}
+
+func init() {
+	aoc.Register(23, aoc.Solution[grid.Grid[Tile], int]{
+		Parse: func(input string) (grid.Grid[Tile], error) {
+			return parseGrid(strings.Split(input, "\n"))
+		},
+		Part1: part1,
+		Part2: part2,
+	})
+}
+
+func part1(ctx context.Context, trails grid.Grid[Tile]) (int, error) {
+	return solve(ctx, trails, true)
+}
+
+func part2(ctx context.Context, trails grid.Grid[Tile]) (int, error) {
+	return solve(ctx, trails, false)
+}
+
+func solve(ctx context.Context, trails grid.Grid[Tile], respectSlopes bool) (int, error) {
+	startCol, err := findStartingTile(trails.Row(0))
+	if err != nil {
+		return 0, fmt.Errorf("could not find starting tile: %w", err)
+	}
+
+	endCol, err := findStartingTile(trails.Row(trails.Height() - 1))
+	if err != nil {
+		return 0, fmt.Errorf("could not find ending tile: %w", err)
+	}
+
+	graph := buildCondensedGraph(
+		grid.Coordinate{Row: 0, Col: startCol},
+		grid.Coordinate{Row: trails.Height() - 1, Col: endCol},
+		trails,
+		respectSlopes,
+	)
+
+	return findLongestPath(
+		ctx,
+		grid.Coordinate{Row: 0, Col: startCol},
+		grid.Coordinate{Row: trails.Height() - 1, Col: endCol},
+		trails,
+		graph,
+	)
+}
+
+func findStartingTile(firstRow []Tile) (int, error) {
+	candidate := (*int)(nil)
+	for col, item := range firstRow {
+		if item == TileEmpty && candidate == nil {
+			savedCol := col
+			candidate = &savedCol
+		} else if item == TileEmpty && candidate != nil {
+			return 0, errors.New("more than one open position")
+		}
+	}
+
+	if candidate == nil {
+		return 0, errors.New("no open positions")
+	}
+
+	return *candidate, nil
+}
+
+func buildCondensedGraph(
+	start, end grid.Coordinate,
+	trails grid.Grid[Tile],
+	respectSlopes bool,
+) map[grid.Coordinate][]GraphNode {
+	toVisit := []grid.Coordinate{start}
+	visited := map[grid.Coordinate]struct{}{}
+	distances := map[grid.Coordinate]int{
+		start: 0,
+	}
+
+	intersections := map[grid.Coordinate]struct{}{start: {}, end: {}}
+	for len(toVisit) > 0 {
+		visiting := toVisit[0]
+		toVisit = toVisit[1:]
+		visited[visiting] = struct{}{}
+
+		validNeighbors := []grid.Coordinate{}
+		for _, neighbor := range findNeighbors(trails, visiting, respectSlopes) {
+			if !trails.InBounds(neighbor) {
+				continue
+			} else if trails.At(neighbor) == TileWall {
+				continue
+			}
+
+			distances[neighbor] = distances[visiting] + 1
+			validNeighbors = append(validNeighbors, neighbor)
+		}
+
+		if len(validNeighbors) > 2 {
+			intersections[visiting] = struct{}{}
+		}
+
+		for _, neighbor := range validNeighbors {
+			if neighbor == end {
+				intersections[neighbor] = struct{}{}
+			}
+
+			if _, ok := visited[neighbor]; !ok {
+				toVisit = append(
+					toVisit,
+					neighbor,
+				)
+			}
+		}
+	}
+
+	res := map[grid.Coordinate][]GraphNode{}
+	for intersection := range intersections {
+		toVisit := []grid.Coordinate{intersection}
+		visited := map[grid.Coordinate]struct{}{}
+		distances := map[grid.Coordinate]int{
+			intersection: 0,
+		}
+
+		for len(toVisit) > 0 {
+			visiting := toVisit[0]
+			toVisit = toVisit[1:]
+			visited[visiting] = struct{}{}
+			for _, neighbor := range findNeighbors(trails, visiting, respectSlopes) {
+				if !trails.InBounds(neighbor) {
+					continue
+				} else if trails.At(neighbor) == TileWall {
+					continue
+				}
+
+				distances[neighbor] = distances[visiting] + 1
+				if _, ok := intersections[neighbor]; ok && neighbor != intersection {
+					res[intersection] = append(
+						res[intersection],
+						GraphNode{Position: neighbor, Weight: distances[neighbor]},
+					)
+				} else if _, ok := visited[neighbor]; !ok {
+					toVisit = append(toVisit, neighbor)
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// findLongestPath finds the length of the longest path from start to end. The search is exhaustive, so it gives up
+// with the context's error if the context is done before it finishes.
+func findLongestPath(
+	ctx context.Context,
+	start grid.Coordinate,
+	end grid.Coordinate,
+	trails grid.Grid[Tile],
+	graph map[grid.Coordinate][]GraphNode,
+) (int, error) {
+	var dfs func(grid.Coordinate, []GraphNode) ([]GraphNode, error)
+	dfs = func(coordinate grid.Coordinate, path []GraphNode) ([]GraphNode, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		children := graph[coordinate]
+		longestPath := path
+		for _, child := range children {
+			if slices.ContainsFunc(path, func(node GraphNode) bool { return node.Position == child.Position }) {
+				continue
+			}
+
+			nextPath := slices.Clone(path)
+			nextPath = append(nextPath, child)
+			fullPath, err := dfs(child.Position, nextPath)
+			if err != nil {
+				return nil, err
+			}
+
+			if sumWeights(fullPath) > sumWeights(longestPath) && fullPath[len(fullPath)-1].Position == end {
+				longestPath = fullPath
+			}
+		}
+
+		return longestPath, nil
+	}
+
+	res, err := dfs(start, []GraphNode{})
+	if err != nil {
+		return 0, err
+	}
+
+	return sumWeights(res), nil
+}
+
+func sumWeights(nodes []GraphNode) int {
+	total := 0
+	for _, node := range nodes {
+		total += node.Weight
+	}
+
+	return total
+}
+
+func findNeighbors(trails grid.Grid[Tile], position grid.Coordinate, respectSlopes bool) []grid.Coordinate {
+	upNeighbor := position.North()
+	downNeighbor := position.South()
+	leftNeighbor := position.West()
+	rightNeighbor := position.East()
+
+	if respectSlopes && trails.At(position) == TileLeft {
+		return []grid.Coordinate{leftNeighbor}
+	} else if respectSlopes && trails.At(position) == TileRight {
+		return []grid.Coordinate{rightNeighbor}
+	} else if respectSlopes && trails.At(position) == TileUp {
+		return []grid.Coordinate{upNeighbor}
+	} else if respectSlopes && trails.At(position) == TileDown {
+		return []grid.Coordinate{downNeighbor}
+	}
+
+	return []grid.Coordinate{upNeighbor, downNeighbor, leftNeighbor, rightNeighbor}
+}
+
+func parseGrid(inputLines []string) (grid.Grid[Tile], error) {
+	if len(inputLines) == 0 {
+		return grid.Grid[Tile]{}, errors.New("cannot parse grid of no length")
+	}
+
+	return grid.Parse(inputLines, func(char rune) (Tile, error) {
+		if Tile(char) != TileWall && Tile(char) != TileEmpty && Tile(char) != TileUp && Tile(char) != TileDown && Tile(char) != TileLeft && Tile(char) != TileRight {
+			return TileEmpty, fmt.Errorf("invalid tile char %c", char)
+		}
+
+		return Tile(char), nil
+	})
+}
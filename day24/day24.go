@@ -0,0 +1,373 @@
+package day24
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"regexp"
+	"slices"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+type Triplet struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+type Hailstone struct {
+	Position Triplet `json:"position"`
+	Velocity Triplet `json:"velocity"`
+}
+
+var (
+	ErrTooFewHailstones = errors.New("at least three hailstones are needed to find the rock")
+	ErrNoRock           = errors.New("no rock with an integer position and velocity hits every hailstone")
+	ErrAnswerTooLarge   = errors.New("answer is too large to fit in an int")
+)
+
+// thrownRock is the position and velocity the rock must be thrown with. Unlike a Hailstone, these are kept exact, as
+// the position can be too large for a float64 to hold every integer near it.
+type thrownRock struct {
+	position [3]*big.Int
+	velocity [3]*big.Int
+}
+
+// TestArea is the square of the X/Y plane in which part 1 looks for the paths of hailstones to cross. Both bounds
+// are inclusive.
+type TestArea struct {
+	Min int
+	Max int
+}
+
+// DefaultTestArea is the test area given by the puzzle
+var DefaultTestArea = TestArea{Min: 200000000000000, Max: 400000000000000}
+
+func init() {
+	testArea := DefaultTestArea
+	aoc.Register(24, aoc.Solution[[]Hailstone, int]{
+		Parse: func(input string) ([]Hailstone, error) {
+			return parse.Lines(input, parseHailstone)
+		},
+		Flags: func(flagSet *flag.FlagSet) {
+			flagSet.IntVar(&testArea.Min, "min", DefaultTestArea.Min, "the smallest X/Y position in part 1's test area")
+			flagSet.IntVar(&testArea.Max, "max", DefaultTestArea.Max, "the largest X/Y position in part 1's test area")
+		},
+		Part1: func(_ context.Context, hailstones []Hailstone) (int, error) {
+			return part1(hailstones, testArea)
+		},
+		Part2: part2,
+		Commands: map[string]aoc.Command[[]Hailstone]{
+			"json": printJSON,
+		},
+	})
+}
+
+func part1(hailstones []Hailstone, testArea TestArea) (int, error) {
+	testMin := new(big.Rat).SetInt64(int64(testArea.Min))
+	testMax := new(big.Rat).SetInt64(int64(testArea.Max))
+	inBounds := func(n *big.Rat) bool {
+		return n.Cmp(testMin) >= 0 && n.Cmp(testMax) <= 0
+	}
+
+	count := 0
+	for i, h1 := range hailstones {
+		for j := i + 1; j < len(hailstones); j++ {
+			h2 := hailstones[j]
+
+			intersection, ok := intersectionPoint(h1, h2)
+			if !ok {
+				continue
+			}
+
+			inFuture := intersection.h1Time.Sign() > 0 && intersection.h2Time.Sign() > 0
+			if inFuture && inBounds(intersection.x) && inBounds(intersection.y) {
+				count++
+			}
+		}
+	}
+
+	return count, nil
+}
+
+func part2(_ context.Context, hailstones []Hailstone) (int, error) {
+	rock, err := findRock(hailstones)
+	if err != nil {
+		return 0, err
+	}
+
+	sum := new(big.Int)
+	for _, coordinate := range rock.position {
+		sum.Add(sum, coordinate)
+	}
+
+	if !sum.IsInt64() || sum.Int64() < math.MinInt || sum.Int64() > math.MaxInt {
+		return 0, fmt.Errorf("%w: %s", ErrAnswerTooLarge, sum)
+	}
+
+	return int(sum.Int64()), nil
+}
+
+func printJSON(hailstones []Hailstone, _ []string, out io.Writer) error {
+	hailstoneJSON, err := json.MarshalIndent(hailstones, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal hailstones: %w", err)
+	}
+
+	fmt.Fprintln(out, string(hailstoneJSON))
+
+	return nil
+}
+
+// intersection is the point at which the paths of two hailstones cross in the X/Y plane, and the times at which
+// each hailstone gets there
+type intersection struct {
+	x      *big.Rat
+	y      *big.Rat
+	h1Time *big.Rat
+	h2Time *big.Rat
+}
+
+// intersectionPoint finds where the paths of the two hailstones cross in the X/Y plane, ignoring Z. Returns false if
+// the paths are parallel.
+func intersectionPoint(h1, h2 Hailstone) (intersection, bool) {
+	p1, v1 := ratTriplet(h1.Position), ratTriplet(h1.Velocity)
+	p2, v2 := ratTriplet(h2.Position), ratTriplet(h2.Velocity)
+	cross2D := func(a, b [3]*big.Rat) *big.Rat {
+		res := new(big.Rat).Mul(a[0], b[1])
+		return res.Sub(res, new(big.Rat).Mul(a[1], b[0]))
+	}
+
+	// Solving p1 + t1*v1 = p2 + t2*v2 for t1 and t2 gives
+	// t1 = ((p2 - p1) x v2) / (v1 x v2), and t2 = ((p2 - p1) x v1) / (v1 x v2)
+	denominator := cross2D(v1, v2)
+	if denominator.Sign() == 0 {
+		return intersection{}, false
+	}
+
+	dp := subRatTriplets(p2, p1)
+	h1Time := new(big.Rat).Quo(cross2D(dp, v2), denominator)
+	h2Time := new(big.Rat).Quo(cross2D(dp, v1), denominator)
+
+	x := new(big.Rat).Mul(v1[0], h1Time)
+	x.Add(x, p1[0])
+	y := new(big.Rat).Mul(v1[1], h1Time)
+	y.Add(y, p1[1])
+
+	return intersection{x: x, y: y, h1Time: h1Time, h2Time: h2Time}, true
+}
+
+// findRock finds the position and velocity a rock must be thrown with to hit every hailstone.
+//
+// If the rock is at P with velocity V, then for it to hit a hailstone at p with velocity v, (P - p) and (V - v) must
+// be parallel, so (P - p) x (V - v) = 0. Expanding this gives P x V - P x v - p x V + p x v = 0, and the only
+// non-linear term, P x V, is the same for every hailstone. Subtracting the equations for two hailstones gives three
+// linear equations in P and V, so three hailstones give us enough to solve for all six unknowns exactly.
+func findRock(hailstones []Hailstone) (thrownRock, error) {
+	if len(hailstones) < 3 {
+		return thrownRock{}, ErrTooFewHailstones
+	}
+
+	// Some combinations of hailstones may not tell us enough (e.g. if two are parallel), so try others until one does
+	for j := 1; j < len(hailstones); j++ {
+		for k := j + 1; k < len(hailstones); k++ {
+			system := append(
+				rockEquations(hailstones[0], hailstones[j]),
+				rockEquations(hailstones[0], hailstones[k])...,
+			)
+
+			solution, ok := solveLinearSystem(system)
+			if !ok {
+				continue
+			}
+
+			rock, ok := integerRock(solution)
+			if !ok || !rockHitsAll(rock, hailstones) {
+				return thrownRock{}, ErrNoRock
+			}
+
+			return rock, nil
+		}
+	}
+
+	return thrownRock{}, ErrNoRock
+}
+
+// rockEquations produces the three equations, P x (v1 - v2) + (p1 - p2) x V = p1 x v1 - p2 x v2, that the rock's
+// position P and velocity V must satisfy to hit both hailstones. Each equation is given as the coefficients of
+// Px, Py, Pz, Vx, Vy, Vz, followed by the constant on the right hand side.
+func rockEquations(h1, h2 Hailstone) [][]*big.Rat {
+	p1, v1 := ratTriplet(h1.Position), ratTriplet(h1.Velocity)
+	p2, v2 := ratTriplet(h2.Position), ratTriplet(h2.Velocity)
+	dv := subRatTriplets(v1, v2)
+	dp := subRatTriplets(p1, p2)
+	rhs := subRatTriplets(crossRatTriplets(p1, v1), crossRatTriplets(p2, v2))
+
+	neg := func(r *big.Rat) *big.Rat {
+		return new(big.Rat).Neg(r)
+	}
+
+	zero := new(big.Rat)
+
+	// P x d = (Py*dz - Pz*dy, Pz*dx - Px*dz, Px*dy - Py*dx), and similarly for d x V
+	return [][]*big.Rat{
+		{zero, dv[2], neg(dv[1]), zero, neg(dp[2]), dp[1], rhs[0]},
+		{neg(dv[2]), zero, dv[0], dp[2], zero, neg(dp[0]), rhs[1]},
+		{dv[1], neg(dv[0]), zero, neg(dp[1]), dp[0], zero, rhs[2]},
+	}
+}
+
+// solveLinearSystem solves the given augmented matrix with Gauss-Jordan elimination, returning false if there is no
+// single solution
+func solveLinearSystem(system [][]*big.Rat) ([]*big.Rat, bool) {
+	// Work on a copy, so that the caller's matrix is left intact
+	matrix := make([][]*big.Rat, len(system))
+	for i, row := range system {
+		matrix[i] = make([]*big.Rat, len(row))
+		for j, value := range row {
+			matrix[i][j] = new(big.Rat).Set(value)
+		}
+	}
+
+	numUnknowns := len(matrix)
+	for col := 0; col < numUnknowns; col++ {
+		pivotRow := slices.IndexFunc(matrix[col:], func(row []*big.Rat) bool {
+			return row[col].Sign() != 0
+		})
+		if pivotRow == -1 {
+			return nil, false
+		}
+
+		matrix[col], matrix[col+pivotRow] = matrix[col+pivotRow], matrix[col]
+
+		pivot := new(big.Rat).Set(matrix[col][col])
+		for j := range matrix[col] {
+			matrix[col][j].Quo(matrix[col][j], pivot)
+		}
+
+		for i, row := range matrix {
+			if i == col || row[col].Sign() == 0 {
+				continue
+			}
+
+			factor := new(big.Rat).Set(row[col])
+			for j := range row {
+				row[j].Sub(row[j], new(big.Rat).Mul(factor, matrix[col][j]))
+			}
+		}
+	}
+
+	solution := make([]*big.Rat, numUnknowns)
+	for i, row := range matrix {
+		solution[i] = row[numUnknowns]
+	}
+
+	return solution, true
+}
+
+// integerRock converts the solution for the rock's position and velocity to a thrownRock, returning false if any of
+// them are not integers
+func integerRock(solution []*big.Rat) (thrownRock, bool) {
+	values := [6]*big.Int{}
+	for i, value := range solution {
+		if !value.IsInt() {
+			return thrownRock{}, false
+		}
+
+		values[i] = new(big.Int).Set(value.Num())
+	}
+
+	return thrownRock{
+		position: [3]*big.Int{values[0], values[1], values[2]},
+		velocity: [3]*big.Int{values[3], values[4], values[5]},
+	}, true
+}
+
+// rockHitsAll checks that the rock's path crosses the path of every hailstone, at the same time as the hailstone
+// gets there
+func rockHitsAll(rock thrownRock, hailstones []Hailstone) bool {
+	rockPosition, rockVelocity := intRatTriplet(rock.position), intRatTriplet(rock.velocity)
+	for _, hailstone := range hailstones {
+		relativePosition := subRatTriplets(rockPosition, ratTriplet(hailstone.Position))
+		relativeVelocity := subRatTriplets(rockVelocity, ratTriplet(hailstone.Velocity))
+		cross := crossRatTriplets(relativePosition, relativeVelocity)
+		if cross[0].Sign() != 0 || cross[1].Sign() != 0 || cross[2].Sign() != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func ratTriplet(triplet Triplet) [3]*big.Rat {
+	return [3]*big.Rat{
+		new(big.Rat).SetFloat64(triplet.X),
+		new(big.Rat).SetFloat64(triplet.Y),
+		new(big.Rat).SetFloat64(triplet.Z),
+	}
+}
+
+func intRatTriplet(triplet [3]*big.Int) [3]*big.Rat {
+	return [3]*big.Rat{
+		new(big.Rat).SetInt(triplet[0]),
+		new(big.Rat).SetInt(triplet[1]),
+		new(big.Rat).SetInt(triplet[2]),
+	}
+}
+
+func subRatTriplets(a, b [3]*big.Rat) [3]*big.Rat {
+	return [3]*big.Rat{
+		new(big.Rat).Sub(a[0], b[0]),
+		new(big.Rat).Sub(a[1], b[1]),
+		new(big.Rat).Sub(a[2], b[2]),
+	}
+}
+
+func crossRatTriplets(a, b [3]*big.Rat) [3]*big.Rat {
+	mulSub := func(w, x, y, z *big.Rat) *big.Rat {
+		res := new(big.Rat).Mul(w, x)
+		return res.Sub(res, new(big.Rat).Mul(y, z))
+	}
+
+	return [3]*big.Rat{
+		mulSub(a[1], b[2], a[2], b[1]),
+		mulSub(a[2], b[0], a[0], b[2]),
+		mulSub(a[0], b[1], a[1], b[0]),
+	}
+}
+
+func parseHailstone(inputLine string) (Hailstone, error) {
+	linePattern := regexp.MustCompile(`^(-?\d+),\s*(-?\d+),\s*(-?\d+)\s*@\s*(-?\d+),\s*(-?\d+),\s*(-?\d+)$`)
+	captures, err := parse.Match(linePattern, inputLine)
+	if err != nil {
+		return Hailstone{}, fmt.Errorf("malformed hailstone: %w", err)
+	}
+
+	matchedNumbers := [6]int{}
+	for i := range matchedNumbers {
+		matchedNumbers[i], err = captures.Int(i + 1)
+		if err != nil {
+			return Hailstone{}, fmt.Errorf("invalid number %s: %w", captures.Group(i+1), err)
+		}
+	}
+
+	return Hailstone{
+		Position: Triplet{
+			X: float64(matchedNumbers[0]),
+			Y: float64(matchedNumbers[1]),
+			Z: float64(matchedNumbers[2]),
+		},
+		Velocity: Triplet{
+			X: float64(matchedNumbers[3]),
+			Y: float64(matchedNumbers[4]),
+			Z: float64(matchedNumbers[5]),
+		},
+	}, nil
+}
@@ -0,0 +1,310 @@
+package day25
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+// CutSize is the number of wires that must be cut to split the components into two groups
+const CutSize = 3
+
+var (
+	ErrNotTwoSections = errors.New("no two distinct sections were found")
+	ErrNoCut          = errors.New("no set of wires splits the components into two groups")
+)
+
+type ParsedComponent struct {
+	Name      string
+	Connected []string
+}
+
+type ParsedCut struct {
+	Node1 string
+	Node2 string
+}
+
+func init() {
+	aoc.Register(25, aoc.Solution[map[string][]string, int]{
+		Parse: func(input string) (map[string][]string, error) {
+			return parseComponents(input)
+		},
+		Part1: func(ctx context.Context, components map[string][]string) (int, error) {
+			cuts, err := findMinCut(ctx, components)
+			if err != nil {
+				return 0, err
+			}
+
+			return part1(components, cuts)
+		},
+		// These can be used to check the cut by hand, by rendering the graph with graphviz (neato). The "cut" command
+		// must be given a space separated list of comma separated edges to cut (e.g. "abc,bcd cde,def")
+		Commands: map[string]aoc.Command[map[string][]string]{
+			"dot": func(components map[string][]string, _ []string, out io.Writer) error {
+				printDOT(components, out)
+
+				return nil
+			},
+			"cut": func(components map[string][]string, args []string, out io.Writer) error {
+				cuts, err := parseCuts(args)
+				if err != nil {
+					return fmt.Errorf("parse cuts: %w", err)
+				}
+
+				answer, err := part1(components, cuts)
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprintf(out, "Part 1: %d\n", answer)
+
+				return nil
+			},
+		},
+	})
+}
+
+func part1(allComponents map[string][]string, cuts []ParsedCut) (int, error) {
+	deleteEdgeBetween := func(components map[string][]string, source, target string) {
+		// The edges are shared with allComponents, so we must not modify them in place
+		sourceEdges := slices.Clone(components[source])
+		components[source] = slices.DeleteFunc(sourceEdges, func(name string) bool {
+			return name == target
+		})
+	}
+
+	trimmedComponents := maps.Clone(allComponents)
+	for _, cut := range cuts {
+		deleteEdgeBetween(trimmedComponents, cut.Node1, cut.Node2)
+		deleteEdgeBetween(trimmedComponents, cut.Node2, cut.Node1)
+	}
+
+	visitedCounts := map[int]struct{}{}
+	fullGraph := buildFullGraph(trimmedComponents)
+
+	for node := range trimmedComponents {
+		visitedCount := numNodesReachableFrom(fullGraph, node)
+		visitedCounts[visitedCount] = struct{}{}
+	}
+
+	// This makes a bit of an assumption, which is that each section will have a different set of reachable nodes
+	// However, I don't think the puzzle is going to have them both doing that, so we make this assumption
+	if len(visitedCounts) != 2 {
+		return 0, ErrNotTwoSections
+	}
+
+	total := 1
+	for count := range visitedCounts {
+		total *= count
+	}
+
+	return total, nil
+}
+
+// findMinCut finds the wires that must be cut to split the components in two. Any two components on opposite sides
+// of the cut can only have CutSize paths between them that don't share a wire, so we look for those with max flow;
+// the cut then lies between the components we can still reach from the source, and those we can't.
+func findMinCut(ctx context.Context, components map[string][]string) ([]ParsedCut, error) {
+	fullGraph := buildFullGraph(components)
+	// Sort the nodes so the search is the same on every run
+	nodes := make([]string, 0, len(fullGraph))
+	for node := range fullGraph {
+		nodes = append(nodes, node)
+	}
+
+	slices.Sort(nodes)
+	if len(nodes) == 0 {
+		return nil, ErrNoCut
+	}
+
+	source := nodes[0]
+	for _, sink := range nodes[1:] {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		flow, reachable := maxFlow(fullGraph, source, sink, CutSize+1)
+		if flow != CutSize {
+			// Either the sink is on the same side as the source, or there is no cut of the right size
+			continue
+		}
+
+		cuts := []ParsedCut{}
+		for _, node := range nodes {
+			if _, ok := reachable[node]; !ok {
+				continue
+			}
+
+			for _, neighbor := range fullGraph[node] {
+				if _, ok := reachable[neighbor]; !ok {
+					cuts = append(cuts, ParsedCut{Node1: node, Node2: neighbor})
+				}
+			}
+		}
+
+		return cuts, nil
+	}
+
+	return nil, ErrNoCut
+}
+
+// maxFlow finds the maximum flow between source and sink, where every edge can carry one unit of flow, stopping once
+// it reaches limit. The nodes that can still be reached from the source once the flow is found are also returned.
+func maxFlow(graph map[string][]string, source, sink string, limit int) (int, map[string]struct{}) {
+	type Edge struct {
+		from string
+		to   string
+	}
+
+	// Flow from a node to another is negative flow in the other direction, so each edge has at most one unit of flow
+	// in either direction, and can take more flow in a direction until it has one unit in it.
+	flows := map[Edge]int{}
+	findAugmentingPath := func() (map[string]string, map[string]struct{}) {
+		cameFrom := map[string]string{}
+		visited := map[string]struct{}{source: {}}
+		toVisit := []string{source}
+		for len(toVisit) > 0 {
+			visiting := toVisit[0]
+			toVisit = toVisit[1:]
+			if visiting == sink {
+				return cameFrom, visited
+			}
+
+			for _, neighbor := range graph[visiting] {
+				if _, ok := visited[neighbor]; ok {
+					continue
+				} else if flows[Edge{from: visiting, to: neighbor}] >= 1 {
+					continue
+				}
+
+				visited[neighbor] = struct{}{}
+				cameFrom[neighbor] = visiting
+				toVisit = append(toVisit, neighbor)
+			}
+		}
+
+		return nil, visited
+	}
+
+	for flow := 0; flow < limit; flow++ {
+		cameFrom, visited := findAugmentingPath()
+		if cameFrom == nil {
+			return flow, visited
+		}
+
+		for node := sink; node != source; node = cameFrom[node] {
+			flows[Edge{from: cameFrom[node], to: node}]++
+			flows[Edge{from: node, to: cameFrom[node]}]--
+		}
+	}
+
+	return limit, nil
+}
+
+func buildFullGraph(components map[string][]string) map[string][]string {
+	graphSets := make(map[string]map[string]struct{})
+	for source, children := range components {
+		for _, child := range children {
+			if graphSets[source] == nil {
+				graphSets[source] = make(map[string]struct{})
+			}
+
+			if graphSets[child] == nil {
+				graphSets[child] = make(map[string]struct{})
+			}
+			graphSets[source][child] = struct{}{}
+			graphSets[child][source] = struct{}{}
+		}
+	}
+
+	fullGraph := make(map[string][]string)
+	for source, children := range graphSets {
+		for child := range children {
+			fullGraph[source] = append(fullGraph[source], child)
+		}
+	}
+
+	return fullGraph
+}
+
+func numNodesReachableFrom(components map[string][]string, source string) int {
+	toVisit := []string{source}
+	visited := map[string]struct{}{}
+	for len(toVisit) > 0 {
+		visiting := toVisit[0]
+		toVisit = toVisit[1:]
+		visited[visiting] = struct{}{}
+
+		for _, neighbor := range components[visiting] {
+			if _, ok := visited[neighbor]; ok {
+				continue
+			}
+
+			toVisit = append(toVisit, neighbor)
+		}
+	}
+
+	return len(visited)
+}
+
+func printDOT(components map[string][]string, out io.Writer) {
+	fmt.Fprintln(out, "graph {")
+	for name, connectedChildren := range components {
+		for _, child := range connectedChildren {
+			fmt.Fprintf(out, "  %s -- %s;\n", name, child)
+		}
+	}
+	fmt.Fprintln(out, "}")
+}
+
+func parseComponents(input string) (map[string][]string, error) {
+	parsedComponents, err := parse.Lines(input, parseComponentLine)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string][]string, len(parsedComponents))
+	for _, component := range parsedComponents {
+		res[component.Name] = component.Connected
+	}
+
+	return res, nil
+}
+
+func parseComponentLine(line string) (ParsedComponent, error) {
+	pattern := regexp.MustCompile(`^([a-z]{3}): ((?:[a-z]{3}\s?)+)$`)
+	captures, err := parse.Match(pattern, line)
+	if err != nil {
+		return ParsedComponent{}, fmt.Errorf("malformed component line: %w", err)
+	}
+
+	return ParsedComponent{
+		Name:      captures.Group(1),
+		Connected: strings.Split(captures.Group(2), " "),
+	}, nil
+}
+
+func parseCuts(cuts []string) ([]ParsedCut, error) {
+	return parse.Each(cuts, parseCut)
+}
+
+func parseCut(cut string) (ParsedCut, error) {
+	pattern := regexp.MustCompile(`^([a-z]{3}),([a-z]{3})$`)
+	captures, err := parse.Match(pattern, cut)
+	if err != nil {
+		return ParsedCut{}, fmt.Errorf("malformed cut %q: %w", cut, err)
+	}
+
+	return ParsedCut{
+		Node1: captures.Group(1),
+		Node2: captures.Group(2),
+	}, nil
+}
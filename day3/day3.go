@@ -0,0 +1,177 @@
+package day3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/grid"
+)
+
+func init() {
+	aoc.Register(3, aoc.Solution[grid.Grid[rune], int]{
+		Parse: func(input string) (grid.Grid[rune], error) {
+			return grid.Parse(strings.Split(input, "\n"), func(r rune) (rune, error) { return r, nil })
+		},
+		Part1: part1,
+		Part2: part2,
+	})
+}
+
+func part1(_ context.Context, schematic grid.Grid[rune]) (int, error) {
+	symbolPositions := schematic.FindAll(isSymbol)
+	partNumberCandidates := []grid.Coordinate{}
+	for _, symbolPos := range symbolPositions {
+		candidates := findNumbersAdjacentTo(schematic, symbolPos)
+		partNumberCandidates = append(partNumberCandidates, candidates...)
+	}
+
+	total := 0
+	// keep track of digits we've already scanned; it's possible one number
+	// is adjacent to two parts, and we don't wanna double count
+	scanned := map[grid.Coordinate]struct{}{}
+	for _, candidate := range partNumberCandidates {
+		if _, ok := scanned[candidate]; ok {
+			continue
+		}
+
+		scannedNumber, scannedDigits, err := scanPartNumber(schematic, candidate)
+		if err != nil {
+			return 0, fmt.Errorf("candidate was invalid: %w", err)
+		}
+
+		for _, scannedDigit := range scannedDigits {
+			scanned[scannedDigit] = struct{}{}
+		}
+
+		total += scannedNumber
+	}
+
+	return total, nil
+}
+
+func part2(_ context.Context, schematic grid.Grid[rune]) (int, error) {
+	gearPositions := schematic.FindAll(isGear)
+	totalRatio := 0
+	for _, gearPos := range gearPositions {
+		partNumbers, err := scanForGearPartNumbers(schematic, gearPos)
+		if err != nil {
+			return 0, fmt.Errorf("gear scan failed: %w", err)
+		}
+
+		if len(partNumbers) == 2 {
+			totalRatio += partNumbers[0] * partNumbers[1]
+		}
+	}
+
+	return totalRatio, nil
+}
+
+// findNumbersAdjacentTo will find all the digit characters adjacent to a given coordinate
+func findNumbersAdjacentTo(schematic grid.Grid[rune], coordinate grid.Coordinate) []grid.Coordinate {
+	coords := []grid.Coordinate{}
+	for _, neighbor := range schematic.AllNeighbors(coordinate) {
+		if unicode.IsDigit(schematic.At(neighbor)) {
+			coords = append(coords, neighbor)
+		}
+	}
+
+	return coords
+}
+
+// scanPartNumber takes an initial digit character and attempts to complete it by scanning left and right from that
+// position. Returns the found part number and the positions scanned.
+func scanPartNumber(schematic grid.Grid[rune], knownDigitPosition grid.Coordinate) (int, []grid.Coordinate, error) {
+	if !unicode.IsDigit(schematic.At(knownDigitPosition)) {
+		return 0, nil, errors.New("position was not a numeric char")
+	}
+
+	line := schematic.Row(knownDigitPosition.Row)
+
+	scanned := []grid.Coordinate{}
+	backwardsBuf := strings.Builder{}
+	// Scan backward
+	for i := knownDigitPosition.Col - 1; i >= 0; i-- {
+		char := line[i]
+		if !unicode.IsDigit(char) {
+			break
+		}
+
+		scanned = append(scanned, grid.Coordinate{Row: knownDigitPosition.Row, Col: i})
+		backwardsBuf.WriteRune(char)
+	}
+
+	forwardsBuf := strings.Builder{}
+	// Scan forward
+	for i := knownDigitPosition.Col; i < len(line); i++ {
+		char := line[i]
+		if !unicode.IsDigit(char) {
+			break
+		}
+
+		scanned = append(scanned, grid.Coordinate{Row: knownDigitPosition.Row, Col: i})
+		forwardsBuf.WriteRune(char)
+	}
+
+	fullNumber := reverseString(backwardsBuf.String()) + forwardsBuf.String()
+	scannedNumber, err := strconv.Atoi(fullNumber)
+	if err != nil {
+		// unicode.IsDigit allows for digits that strconv does not
+		return 0, nil, fmt.Errorf("%s was not a number: %w", fullNumber, err)
+	}
+
+	return scannedNumber, scanned, nil
+}
+
+// scanForGearPartNumbers will find all part numbers adjacent to the given gear character position
+func scanForGearPartNumbers(schematic grid.Grid[rune], gearPosition grid.Coordinate) ([]int, error) {
+	if !isGear(schematic.At(gearPosition)) {
+		return nil, errors.New("position is not gear")
+	}
+
+	candidates := findNumbersAdjacentTo(schematic, gearPosition)
+	// Keep track of the digits scanned, as it is possible for a single part number to be adjacent
+	// to the gear in multiple places
+	scanned := map[grid.Coordinate]struct{}{}
+
+	adjacentPartNumbers := []int{}
+	for _, candidate := range candidates {
+		if _, ok := scanned[candidate]; ok {
+			continue
+		}
+
+		scannedNumber, scannedDigits, err := scanPartNumber(schematic, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("candidate was invalid: %w", err)
+		}
+
+		for _, scannedDigit := range scannedDigits {
+			scanned[scannedDigit] = struct{}{}
+		}
+
+		adjacentPartNumbers = append(adjacentPartNumbers, scannedNumber)
+	}
+
+	return adjacentPartNumbers, nil
+}
+
+func isSymbol(r rune) bool {
+	return r != '.' && !unicode.IsDigit(r)
+}
+
+func isGear(r rune) bool {
+	return r == '*'
+}
+
+func reverseString(s string) string {
+	buffer := strings.Builder{}
+	for i := len(s) - 1; i >= 0; i-- {
+		buffer.WriteByte(s[i])
+	}
+
+	return buffer.String()
+}
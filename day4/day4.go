@@ -1,14 +1,15 @@
-package main
+package day4
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"os"
 	"regexp"
 	"slices"
 	"strconv"
-	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
 )
 
 type Card struct {
@@ -17,6 +18,8 @@ type Card struct {
 	ourNumbers     []int
 }
 
+var ErrMissingCard = errors.New("won a card that is not in the table")
+
 func (card Card) NumMatchingNumbers() int {
 	matchingNumbers := 0
 
@@ -50,48 +53,28 @@ func (card Card) WinsCardsWithIDs() []int {
 	return wonCards
 }
 
-func main() {
-	if len(os.Args) != 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s inputfile\n", os.Args[0])
-		os.Exit(1)
-	}
-
-	inputFilename := os.Args[1]
-	inputFile, err := os.Open(inputFilename)
-	if err != nil {
-		panic(fmt.Sprintf("could not open input file: %s", err))
-	}
-
-	defer inputFile.Close()
-
-	inputBytes, err := io.ReadAll(inputFile)
-	if err != nil {
-		panic(fmt.Sprintf("could not read input file: %s", err))
-	}
-
-	input := string(inputBytes)
-	inputLines := strings.Split(strings.TrimSpace(input), "\n")
-	cards, err := parseCards(inputLines)
-	if err != nil {
-		panic(fmt.Sprintf("could not parse input: %s", err))
-	}
-
-	fmt.Printf("Part 1: %d\n", part1(cards))
-	fmt.Printf("Part 2: %d\n", part2(cards))
+func init() {
+	aoc.Register(4, aoc.Solution[[]Card, int]{
+		Parse: func(input string) ([]Card, error) {
+			return parse.Lines(input, parseCard)
+		},
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func part1(cards []Card) int {
+func part1(_ context.Context, cards []Card) (int, error) {
 	score := 0
 	for _, card := range cards {
 		score += card.Score()
 	}
 
-	return score
+	return score, nil
 }
 
-func part2(cards []Card) int {
+func part2(_ context.Context, cards []Card) (int, error) {
 	if len(cards) == 0 {
-		return 0
+		return 0, nil
 	}
 
 	cardsByID := map[int]Card{}
@@ -116,7 +99,12 @@ func part2(cards []Card) int {
 
 		wonCardIDs := card.WinsCardsWithIDs()
 		for _, wonCardID := range wonCardIDs {
-			cardsInPlay = append(cardsInPlay, cardsByID[wonCardID])
+			wonCard, ok := cardsByID[wonCardID]
+			if !ok {
+				return 0, fmt.Errorf("%w: card %d won card %d", ErrMissingCard, card.id, wonCardID)
+			}
+
+			cardsInPlay = append(cardsInPlay, wonCard)
 		}
 	}
 
@@ -125,31 +113,27 @@ func part2(cards []Card) int {
 		totalCards += numVisited
 	}
 
-	return totalCards
-}
-
-func parseCards(inputLines []string) ([]Card, error) {
-	return tryParse(inputLines, parseCard)
+	return totalCards, nil
 }
 
 func parseCard(inputLine string) (Card, error) {
 	pattern := regexp.MustCompile(`^Card\s+(\d+): ((?:\s*\d+\s*?)+) \| ((?:\s*\d+\s*)+)$`)
-	matches := pattern.FindStringSubmatch(inputLine)
-	if matches == nil {
-		return Card{}, errors.New("did not match line pattern")
+	captures, err := parse.Match(pattern, inputLine)
+	if err != nil {
+		return Card{}, fmt.Errorf("did not match line pattern: %w", err)
 	}
 
-	id, err := strconv.Atoi(matches[1])
+	id, err := captures.Int(1)
 	if err != nil {
 		return Card{}, fmt.Errorf("parse id: %w", err)
 	}
 
-	winningNumbers, err := parseCardNumbers(matches[2])
+	winningNumbers, err := parse.Capture(captures, 2, parseCardNumbers)
 	if err != nil {
 		return Card{}, fmt.Errorf("parse winning numbers: %w", err)
 	}
 
-	ourNumbers, err := parseCardNumbers(matches[3])
+	ourNumbers, err := parse.Capture(captures, 3, parseCardNumbers)
 	if err != nil {
 		return Card{}, fmt.Errorf("parse our numbers: %w", err)
 	}
@@ -161,32 +145,8 @@ func parseCard(inputLine string) (Card, error) {
 	}, nil
 }
 
-func normalizeSeparatingSpaces(s string) string {
-	pattern := regexp.MustCompile(`\s{2,}`)
-
-	return pattern.ReplaceAllString(s, " ")
-}
-
 func parseCardNumbers(numbers string) ([]int, error) {
-	normalizedNumbers := normalizeSeparatingSpaces(numbers)
-	trimmedNumbers := strings.TrimSpace(normalizedNumbers)
-	splitNumbers := strings.Split(trimmedNumbers, " ")
-
-	return tryParse(splitNumbers, strconv.Atoi)
-}
-
-func tryParse[T any](items []string, doParse func(s string) (T, error)) ([]T, error) {
-	res := []T{}
-	for i, line := range items {
-		parsedItem, err := doParse(line)
-		if err != nil {
-			return nil, fmt.Errorf("malformed item at index %d: %w", i, err)
-		}
-
-		res = append(res, parsedItem)
-	}
-
-	return res, nil
+	return parse.Fields(numbers, strconv.Atoi)
 }
 
 func makeSet[T comparable, S ~[]T](items S) map[T]struct{} {
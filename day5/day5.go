@@ -0,0 +1,475 @@
+package day5
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+var (
+	ErrMissingConversion = errors.New("almanac is missing a conversion")
+	ErrNoLocation        = errors.New("no seeds grow into a location")
+)
+
+var conversionSteps = []ConvertsBetween{
+	{from: "seed", to: "soil"},
+	{from: "soil", to: "fertilizer"},
+	{from: "fertilizer", to: "water"},
+	{from: "water", to: "light"},
+	{from: "light", to: "temperature"},
+	{from: "temperature", to: "humidity"},
+	{from: "humidity", to: "location"},
+}
+
+type Range struct {
+	start int
+	size  int
+}
+
+type ConversionMapEntry struct {
+	destRange Range
+	srcRange  Range
+}
+
+type ConversionMap []ConversionMapEntry
+
+type ConvertsBetween struct {
+	from string
+	to   string
+}
+
+// ConversionSection is a single section of the almanac, which describes how to convert from one kind of item to another
+type ConversionSection struct {
+	convertsBetween ConvertsBetween
+	conversionMap   ConversionMap
+}
+
+type Almanac struct {
+	seeds       []int
+	conversions map[ConvertsBetween]ConversionMap
+}
+
+type WorkerData struct {
+	startLocation int
+	numToProcess  int
+}
+
+// Contains checks if the given value is contained in the range
+func (r Range) Contains(n int) bool {
+	return n >= r.start && n < r.start+r.size
+}
+
+// End gets the first value after the range
+func (r Range) End() int {
+	return r.start + r.size
+}
+
+// Empty checks if the range contains no values
+func (r Range) Empty() bool {
+	return r.size <= 0
+}
+
+// Intersection finds the values that are in both ranges. The result may be empty.
+func (r Range) Intersection(other Range) Range {
+	start := max(r.start, other.start)
+	end := min(r.End(), other.End())
+
+	return Range{start: start, size: max(end-start, 0)}
+}
+
+// RangeDelta indicates how large the span of the range starts are for this entry
+func (entry ConversionMapEntry) RangeDelta() int {
+	return entry.destRange.start - entry.srcRange.start
+}
+
+// ConvertsTo executes the "conversion" of this step, as defined by the problem
+func (conversionMap ConversionMap) ConvertsTo(n int) int {
+	for _, entry := range conversionMap {
+		if entry.srcRange.Contains(n) {
+			return entry.RangeDelta() + n
+		}
+	}
+
+	return n
+}
+
+// ConvertRanges executes the conversion on every value in the given ranges. Each range is split at the boundaries of
+// the entries, so that every piece is converted by exactly one entry (or none at all).
+func (conversionMap ConversionMap) ConvertRanges(ranges []Range) []Range {
+	converted := []Range{}
+	unconverted := slices.Clone(ranges)
+	for _, entry := range conversionMap {
+		remaining := []Range{}
+		for _, r := range unconverted {
+			overlap := r.Intersection(entry.srcRange)
+			if overlap.Empty() {
+				remaining = append(remaining, r)
+				continue
+			}
+
+			converted = append(converted, Range{start: overlap.start + entry.RangeDelta(), size: overlap.size})
+
+			// Anything on either side of the entry may still be converted by another one
+			before := Range{start: r.start, size: overlap.start - r.start}
+			after := Range{start: overlap.End(), size: r.End() - overlap.End()}
+			for _, leftover := range []Range{before, after} {
+				if !leftover.Empty() {
+					remaining = append(remaining, leftover)
+				}
+			}
+		}
+
+		unconverted = remaining
+	}
+
+	// Anything that isn't in an entry maps to itself
+	return append(converted, unconverted...)
+}
+
+// ReverseConversion is the onverse of ConvertsTo
+func (conversionMap ConversionMap) ReverseConversion(n int) int {
+	for _, entry := range conversionMap {
+		if entry.destRange.Contains(n) {
+			return n - entry.RangeDelta()
+		}
+	}
+
+	return n
+}
+
+func init() {
+	workSize := 1000
+	bruteForce := false
+	aoc.Register(5, aoc.Solution[Almanac, int]{
+		Parse: parseAlmanac,
+		Flags: func(flagSet *flag.FlagSet) {
+			flagSet.BoolVar(&bruteForce, "bruteforce", false, "solve part 2 by checking every location, which is useful for cross-checking")
+			flagSet.IntVar(&workSize, "worksize", 1000, "the number of locations each part 2 worker checks at a time (with --bruteforce)")
+		},
+		Part1: func(_ context.Context, almanac Almanac) (int, error) {
+			return part1(almanac.seeds, almanac.conversions)
+		},
+		Part2: func(ctx context.Context, almanac Almanac) (int, error) {
+			if !bruteForce {
+				return part2(almanac.seeds, almanac.conversions)
+			}
+
+			// I got lazy here
+			fmt.Fprintln(os.Stderr, "Warning: Part 2 does not halt in the absence of a solution, so it taking a long time does not mean it will eventually find it")
+			return part2BruteForce(ctx, almanac.seeds, almanac.conversions, workSize)
+		},
+	})
+}
+
+func part1(seeds []int, conversions map[ConvertsBetween]ConversionMap) (int, error) {
+	min := math.MaxInt
+	for _, seed := range seeds {
+		location, err := growPlant(seed, conversions)
+		if err != nil {
+			return 0, fmt.Errorf("grow seed %d: %w", seed, err)
+		}
+
+		if location < min {
+			min = location
+		}
+	}
+
+	return min, nil
+}
+
+func part2(seeds []int, conversions map[ConvertsBetween]ConversionMap) (int, error) {
+	ranges, err := makeSeedRanges(seeds)
+	if err != nil {
+		return 0, fmt.Errorf("make seed ranges: %w", err)
+	}
+
+	for _, conversionStep := range conversionSteps {
+		conversionMap, ok := conversions[conversionStep]
+		if !ok {
+			return 0, missingConversionError(conversionStep)
+		}
+
+		ranges = conversionMap.ConvertRanges(ranges)
+	}
+
+	minLocation := math.MaxInt
+	for _, r := range ranges {
+		if !r.Empty() && r.start < minLocation {
+			minLocation = r.start
+		}
+	}
+
+	if minLocation == math.MaxInt {
+		return 0, ErrNoLocation
+	}
+
+	return minLocation, nil
+}
+
+// part2BruteForce solves part 2 by checking every location, in order, to see if it can be grown from a seed. It gives
+// up with the context's error if the context is done before the answer is found.
+func part2BruteForce(
+	parentCtx context.Context,
+	seeds []int,
+	conversions map[ConvertsBetween]ConversionMap,
+	workSize int,
+) (int, error) {
+	seedRanges, err := makeSeedRanges(seeds)
+	if err != nil {
+		return 0, fmt.Errorf("make seed ranges: %w", err)
+	}
+
+	for _, conversionStep := range conversionSteps {
+		if _, ok := conversions[conversionStep]; !ok {
+			return 0, missingConversionError(conversionStep)
+		}
+	}
+
+	answerChan := make(chan int)
+	workChan := make(chan WorkerData)
+
+	ctx, cancel := context.WithCancel(parentCtx)
+	numWorkers := runtime.NumCPU()
+	wg := sync.WaitGroup{}
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			part2Worker(ctx, seedRanges, conversions, workChan, answerChan)
+			wg.Done()
+		}()
+	}
+
+	dispatchCtx, cancelDispatch := context.WithCancel(ctx)
+	go func() {
+		dispatchPart2Work(dispatchCtx, workChan, workSize)
+		// Wait for all workers to finish, and then close the answer chan so that we know they're gone
+		wg.Wait()
+		close(answerChan)
+	}()
+
+	bestAnswer := math.MaxInt
+	for answer := range answerChan {
+		// We may get multiple possible answers, but we only wanna take the min
+		if answer < bestAnswer {
+			bestAnswer = answer
+			// Once we get an answer, stop dispatching work
+			cancelDispatch()
+		}
+	}
+
+	cancel()
+	// This isn't really needed because we cancel the parent ctx but it satisfies the linter
+	cancelDispatch()
+
+	// If we were stopped early, the workers may not have gotten to the smallest answer
+	if err := parentCtx.Err(); err != nil {
+		return 0, err
+	}
+
+	return bestAnswer, nil
+}
+
+// growPlant will grow a plant from a seed through all the stages until all the conversions are complete
+func growPlant(seed int, conversions map[ConvertsBetween]ConversionMap) (int, error) {
+	item := seed
+	for _, conversionStep := range conversionSteps {
+		conversionMap, ok := conversions[conversionStep]
+		if !ok {
+			return 0, missingConversionError(conversionStep)
+		}
+
+		item = conversionMap.ConvertsTo(item)
+	}
+
+	return item, nil
+}
+
+func missingConversionError(conversionStep ConvertsBetween) error {
+	return fmt.Errorf("%w: %s-to-%s", ErrMissingConversion, conversionStep.from, conversionStep.to)
+}
+
+// dispatchPart2Work will dispatch work to the given workChan. It will give workSize number of items
+// for each piece of worker data, allowing fair distribution of work between goroutines
+func dispatchPart2Work(ctx context.Context, workChan chan WorkerData, workSize int) {
+	for i := 0; ; i += workSize {
+		data := WorkerData{
+			startLocation: i,
+			numToProcess:  workSize,
+		}
+
+		select {
+		case <-ctx.Done():
+			close(workChan)
+			return
+		case workChan <- data:
+		}
+	}
+}
+
+// part2Worker solves part 2 for a given
+func part2Worker(ctx context.Context, seedRanges []Range, conversions map[ConvertsBetween]ConversionMap, workChan <-chan WorkerData, answerChan chan<- int) {
+	for workerData := range workChan {
+		// fmt.Printf("Got work: %+v\n", workerData)
+		for i := 0; i < workerData.numToProcess; i++ {
+			// Stop working if context says we're done
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			location := workerData.startLocation + i
+			canReverse, err := canGrowLocation(seedRanges, conversions, location)
+			if err != nil {
+				// We can't do a ton here without a bunch of error plumbing that is too much work for an AoC problem.
+				// An operator will see the error and handle it  :)
+				fmt.Fprintf(os.Stderr, "Cannot reverse growth for location %d\n", location)
+				continue
+			}
+
+			if canReverse {
+				select {
+				case <-ctx.Done():
+					return
+				case answerChan <- location:
+				}
+			}
+		}
+	}
+}
+
+// canGrowLocation indicates whether or not the location can be grown with the given seedRanges
+func canGrowLocation(seedRanges []Range, conversions map[ConvertsBetween]ConversionMap, location int) (bool, error) {
+	currentItem := location
+	for i := len(conversionSteps) - 1; i >= 0; i-- {
+		step := conversionSteps[i]
+		conversionMap, ok := conversions[step]
+		if !ok {
+			return false, missingConversionError(step)
+		}
+
+		currentItem = conversionMap.ReverseConversion(currentItem)
+	}
+
+	idx := slices.IndexFunc(seedRanges, func(r Range) bool {
+		return r.Contains(currentItem)
+	})
+
+	return idx != -1, nil
+}
+
+// makeSeedRanges will convert a set of seed input values to ranges (only needed for part 2)
+func makeSeedRanges(seeds []int) ([]Range, error) {
+	if len(seeds)%2 != 0 {
+		return nil, errors.New("number of seed entries must be even")
+	}
+
+	seedRanges := make([]Range, 0, len(seeds)/2)
+	for i := 0; i < len(seeds); i += 2 {
+		rangeStart := seeds[i]
+		rangeSize := seeds[i+1]
+
+		seedRanges = append(seedRanges, Range{start: rangeStart, size: rangeSize})
+	}
+
+	return seedRanges, nil
+}
+
+func parseAlmanac(input string) (Almanac, error) {
+	seedSection, conversionSections, _ := strings.Cut(input, "\n\n")
+	seeds, err := parseSeeds(seedSection)
+	if err != nil {
+		return Almanac{}, parse.AtLine(fmt.Errorf("parse seeds: %w", err), 1)
+	}
+
+	sections, err := parse.Sections(conversionSections, parseConversionSection)
+	if err != nil {
+		// The conversion sections start after the seeds and the blank line that follows them
+		return Almanac{}, parse.AtLine(fmt.Errorf("parse section: %w", err), strings.Count(seedSection, "\n")+3)
+	}
+
+	conversions := map[ConvertsBetween]ConversionMap{}
+	for _, section := range sections {
+		conversions[section.convertsBetween] = section.conversionMap
+	}
+
+	return Almanac{seeds: seeds, conversions: conversions}, nil
+}
+
+func parseSeeds(seedSection string) ([]int, error) {
+	pattern := regexp.MustCompile(`^seeds: (.*)$`)
+	captures, err := parse.Match(pattern, seedSection)
+	if err != nil {
+		return nil, fmt.Errorf("missing seeds prefix: %w", err)
+	}
+
+	seedNumbers, err := captures.Ints(1)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed numbers: %w", err)
+	}
+
+	return seedNumbers, nil
+}
+
+func parseConversionSection(section string) (ConversionSection, error) {
+	heading, rawConversionMap, found := strings.Cut(section, "\n")
+	if !found {
+		return ConversionSection{}, errors.New("not enough information in section")
+	}
+
+	convertsBetween, err := parseSectionHeading(heading)
+	if err != nil {
+		return ConversionSection{}, fmt.Errorf("invalid section heading: %w", err)
+	}
+
+	conversionMap, err := parse.Lines(rawConversionMap, parseConversionMapEntry)
+	if err != nil {
+		// The entries start on the line after the heading
+		return ConversionSection{}, parse.AtLine(fmt.Errorf("invalid conversion: %w", err), 2)
+	}
+
+	return ConversionSection{convertsBetween: convertsBetween, conversionMap: conversionMap}, nil
+}
+
+func parseSectionHeading(heading string) (ConvertsBetween, error) {
+	pattern := regexp.MustCompile(`^(\w+)-to-(\w+) map:`)
+	captures, err := parse.Match(pattern, heading)
+	if err != nil {
+		return ConvertsBetween{}, fmt.Errorf("malformed heading: %w", err)
+	}
+
+	return ConvertsBetween{
+		from: captures.Group(1),
+		to:   captures.Group(2),
+	}, nil
+}
+
+func parseConversionMapEntry(line string) (ConversionMapEntry, error) {
+	entryNumbers, err := parse.Fields(line, strconv.Atoi)
+	if err != nil {
+		return ConversionMapEntry{}, fmt.Errorf("conversion map entry numbers: %w", err)
+	} else if len(entryNumbers) != 3 {
+		return ConversionMapEntry{}, fmt.Errorf("expected 3 numbers in a conversion section, got %d", len(entryNumbers))
+	}
+
+	dest := entryNumbers[0]
+	src := entryNumbers[1]
+	size := entryNumbers[2]
+
+	return ConversionMapEntry{
+		destRange: Range{start: dest, size: size},
+		srcRange:  Range{start: src, size: size},
+	}, nil
+}
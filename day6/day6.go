@@ -0,0 +1,156 @@
+package day6
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+type Race struct {
+	time           int
+	recordDistance int
+}
+
+func init() {
+	aoc.Register(6, aoc.Solution[[]Race, int]{
+		Parse: parseRaces,
+		Part1: part1,
+		Part2: part2,
+	})
+}
+
+func part1(_ context.Context, races []Race) (int, error) {
+	res := 1
+	for _, race := range races {
+		res *= numberOfWaysToWinRace(race)
+	}
+
+	return res, nil
+}
+
+func part2(_ context.Context, races []Race) (int, error) {
+	bigRace, err := combineRaces(races)
+	if err != nil {
+		return 0, fmt.Errorf("combine races: %w", err)
+	}
+
+	return numberOfWaysToWinRace(bigRace), nil
+}
+
+func numberOfWaysToWinRace(race Race) int {
+	possibleWins := 0
+	for timeHeld := 0; timeHeld <= race.time; timeHeld++ {
+		distance := distanceForTimeHeld(timeHeld, race.time)
+		if distance > race.recordDistance {
+			possibleWins++
+		}
+	}
+
+	return possibleWins
+}
+
+func distanceForTimeHeld(buttonHeld int, raceTime int) int {
+	timeLeft := raceTime - buttonHeld
+	speed := buttonHeld
+
+	return speed * timeLeft
+}
+
+func parseRaces(input string) ([]Race, error) {
+	lines := strings.Split(input, "\n")
+	if len(lines) != 2 {
+		return nil, errors.New("input must be exactly two lines")
+	}
+
+	timeLineComponents, err := parseRow(lines[0], "Time")
+	if err != nil {
+		return nil, parse.AtLine(fmt.Errorf("malformed 'time' line: %w", err), 1)
+	}
+
+	distanceLineComponents, err := parseRow(lines[1], "Distance")
+	if err != nil {
+		return nil, parse.AtLine(fmt.Errorf("malformed 'distance' line: %w", err), 2)
+	}
+
+	if len(timeLineComponents) != len(distanceLineComponents) {
+		return nil, errors.New("'time' and 'distance' lines have a different number of elements")
+	}
+
+	races := make([]Race, len(timeLineComponents))
+	for i := 0; i < len(timeLineComponents); i++ {
+		timeComponent := timeLineComponents[i]
+		distanceComponent := distanceLineComponents[i]
+		races[i] = Race{
+			time:           timeComponent,
+			recordDistance: distanceComponent,
+		}
+	}
+
+	return races, nil
+}
+
+// parseRow parses the numbers in a single row of the race table, which must start with the given prefix
+func parseRow(s string, prefix string) ([]int, error) {
+	pattern := regexp.MustCompile("^" + regexp.QuoteMeta(prefix) + `:\s*(.*)$`)
+	captures, err := parse.Match(pattern, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return captures.Ints(1)
+}
+
+func combineRaces(races []Race) (Race, error) {
+	if len(races) == 0 {
+		return Race{}, errors.New("cannot combine zero races into one")
+	}
+
+	raceTimes := make([]int, len(races))
+	raceRecords := make([]int, len(races))
+	for i, race := range races {
+		raceTimes[i] = race.time
+		raceRecords[i] = race.recordDistance
+	}
+
+	bigRaceTime, err := smashNumbers(raceTimes)
+	if err != nil {
+		return Race{}, fmt.Errorf("combine times: %w", err)
+	}
+
+	bigRaceRecord, err := smashNumbers(raceRecords)
+	if err != nil {
+		return Race{}, fmt.Errorf("combine records: %w", err)
+	}
+
+	return Race{
+		time:           bigRaceTime,
+		recordDistance: bigRaceRecord,
+	}, nil
+}
+
+// smashNumbers combines the digits of each number into one big number. This fails if the numbers are negative, or
+// if the big number is too large to hold.
+func smashNumbers(nums []int) (int, error) {
+	if len(nums) == 0 {
+		// programmer error
+		panic("cannot combine zero numbers into a big one")
+	}
+
+	s := ""
+	for _, n := range nums {
+		s += strconv.Itoa(n)
+	}
+
+	bigNum, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid number: %w", s, err)
+	}
+
+	return bigNum, nil
+}
@@ -1,14 +1,16 @@
-package main
+package day7
 
 import (
 	"cmp"
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
 )
 
 type Card int
@@ -45,6 +47,8 @@ const (
 
 type Hand []Card
 
+var ErrUnknownKind = errors.New("no known kind for hand")
+
 type Player struct {
 	bid  int
 	hand Hand
@@ -99,7 +103,7 @@ func (hand Hand) Kind() (HandKind, error) {
 		}
 	}
 
-	return UnknownKind, errors.New("no known kind for hand")
+	return UnknownKind, ErrUnknownKind
 }
 
 // WithoutCard will return a copy of the Hand without the given card, toRemove
@@ -128,78 +132,59 @@ func (hand Hand) CountDistinct() map[Card]int {
 	return buckets
 }
 
-func main() {
-	if len(os.Args) != 2 && len(os.Args) != 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s inputfile\n", os.Args[0])
-		os.Exit(1)
-	}
-
-	inputFilename := os.Args[1]
-	inputFile, err := os.Open(inputFilename)
-	if err != nil {
-		panic(fmt.Sprintf("could not open input file: %s", err))
-	}
-
-	defer inputFile.Close()
-
-	inputBytes, err := io.ReadAll(inputFile)
-	if err != nil {
-		panic(fmt.Sprintf("could not read input file: %s", err))
-	}
-
-	input := strings.TrimSpace(string(inputBytes))
-	if err != nil {
-		panic(fmt.Sprintf("failed to parse input: %s", err))
-	}
-
-	inputLines := strings.Split(input, "\n")
-	players, err := parsePlayers(inputLines)
-	if err != nil {
-		panic(fmt.Sprintf("failed to parse races: %s", err))
-	}
-
-	fmt.Printf("Part 1: %d\n", part1(players))
-	fmt.Printf("Part 2: %d\n", part2(players))
+func init() {
+	aoc.Register(7, aoc.Solution[[]Player, int]{
+		Parse: func(input string) ([]Player, error) {
+			return parse.Lines(input, parsePlayer)
+		},
+		Part1: part1,
+		Part2: part2,
+	})
 }
 
-func part1(players []Player) int {
+func part1(_ context.Context, players []Player) (int, error) {
 	return findWinnings(players)
 }
 
-func part2(originalPlayers []Player) int {
+func part2(_ context.Context, originalPlayers []Player) (int, error) {
 	players := makePart2Players(originalPlayers)
 
 	return findWinnings(players)
 }
 
 // findWinnings finds the winning for each game
-func findWinnings(players []Player) int {
-	sortedPlayers := slices.Clone(players)
-	slices.SortFunc(sortedPlayers, func(a, b Player) int {
-		aKind, err := a.hand.Kind()
-		if err != nil {
-			panic(fmt.Errorf("invalid hand %+v: %w", a, err))
-		}
+func findWinnings(players []Player) (int, error) {
+	type rankedPlayer struct {
+		player Player
+		kind   HandKind
+	}
 
-		bKind, err := b.hand.Kind()
+	// Find the kinds before sorting, so that we can bail out if any of them are invalid
+	rankedPlayers := make([]rankedPlayer, 0, len(players))
+	for _, player := range players {
+		kind, err := player.hand.Kind()
 		if err != nil {
-			panic(fmt.Errorf("invalid hand %+v: %w", b, err))
+			return 0, fmt.Errorf("invalid hand %+v: %w", player, err)
 		}
 
-		compareHands := cmp.Compare(aKind, bKind)
+		rankedPlayers = append(rankedPlayers, rankedPlayer{player: player, kind: kind})
+	}
+
+	slices.SortFunc(rankedPlayers, func(a, b rankedPlayer) int {
+		compareHands := cmp.Compare(a.kind, b.kind)
 		if compareHands == 0 {
-			return slices.Compare(a.hand, b.hand)
+			return slices.Compare(a.player.hand, b.player.hand)
 		} else {
 			return compareHands
 		}
 	})
 
 	winnings := 0
-	for i, player := range sortedPlayers {
-		winnings += (i + 1) * player.bid
+	for i, ranked := range rankedPlayers {
+		winnings += (i + 1) * ranked.player.bid
 	}
 
-	return winnings
+	return winnings, nil
 }
 
 // makePart2Players prepares the players for part 2 by replacing Jacks with Jokers
@@ -261,20 +246,6 @@ func permutationsOfJokers(distinctCardCounts []int, numJokers int) [][]int {
 	return permutations
 }
 
-func parsePlayers(inputLines []string) ([]Player, error) {
-	players := make([]Player, 0, len(inputLines))
-	for i, line := range inputLines {
-		player, err := parsePlayer(line)
-		if err != nil {
-			return nil, fmt.Errorf("parse line %d: %w", i, err)
-		}
-
-		players = append(players, player)
-	}
-
-	return players, nil
-}
-
 func parsePlayer(inputLine string) (Player, error) {
 	lineComponents := strings.Split(inputLine, " ")
 	if len(lineComponents) != 2 {
@@ -288,12 +259,12 @@ func parsePlayer(inputLine string) (Player, error) {
 
 	bid, err := strconv.Atoi(lineComponents[1])
 	if err != nil {
-		return Player{}, fmt.Errorf("parse bid: %w", err)
+		// The bid comes after the hand and the space that separates them
+		return Player{}, parse.AtColumn(fmt.Errorf("parse bid: %w", err), len(lineComponents[0])+2)
 	}
 
 	return Player{bid: bid, hand: hand}, nil
 }
-
 func parseHand(handStr string) (Hand, error) {
 	cardMap := map[byte]Card{
 		'A': Ace,
@@ -312,10 +283,10 @@ func parseHand(handStr string) (Hand, error) {
 	}
 
 	hand := Hand{}
-	for _, handChar := range handStr {
+	for i, handChar := range handStr {
 		card, ok := cardMap[byte(handChar)]
 		if !ok {
-			return nil, fmt.Errorf("invalid card character %c", handChar)
+			return nil, parse.AtColumn(fmt.Errorf("invalid card character %c", handChar), i+1)
 		}
 
 		hand = append(hand, card)
@@ -1,12 +1,14 @@
-package main
+package day8
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io"
-	"os"
 	"regexp"
 	"strings"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
 )
 
 type Direction int
@@ -17,6 +19,16 @@ const (
 	DirectionRight
 )
 
+var (
+	ErrMissingNode    = errors.New("node is not in the map")
+	ErrNoStartingNode = errors.New("no starting nodes")
+)
+
+type Map struct {
+	directions []Direction
+	nodes      map[NodeAddress]NodeChoice
+}
+
 type NodeChoice struct {
 	left  NodeAddress
 	right NodeAddress
@@ -34,46 +46,19 @@ func (choice NodeChoice) TakeDirection(direction Direction) NodeAddress {
 	}
 }
 
-func main() {
-	if len(os.Args) != 2 && len(os.Args) != 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s inputfile\n", os.Args[0])
-		os.Exit(1)
-	}
-
-	inputFilename := os.Args[1]
-	inputFile, err := os.Open(inputFilename)
-	if err != nil {
-		panic(fmt.Sprintf("could not open input file: %s", err))
-	}
-
-	defer inputFile.Close()
-
-	inputBytes, err := io.ReadAll(inputFile)
-	if err != nil {
-		panic(fmt.Sprintf("could not read input file: %s", err))
-	}
-
-	input := strings.TrimSpace(string(inputBytes))
-	inputLines := strings.Split(input, "\n")
-	if len(inputLines) < 3 {
-		panic("not enough data in input to parse")
-	}
-
-	directions, err := parseDirectionLine(inputLines[0])
-	if err != nil {
-		panic(fmt.Sprintf("failed to parse direction lien: %s", err))
-	}
-
-	nodeMap, err := parseMap(inputLines[2:])
-	if err != nil {
-		panic(fmt.Sprintf("failed to parse map: %s", err))
-	}
-
-	fmt.Printf("Part 1: %d\n", part1(directions, nodeMap))
-	fmt.Printf("Part 2: %d\n", part2(directions, nodeMap))
+func init() {
+	aoc.Register(8, aoc.Solution[Map, int]{
+		Parse: parseInput,
+		Part1: func(ctx context.Context, m Map) (int, error) {
+			return part1(ctx, m.directions, m.nodes)
+		},
+		Part2: func(ctx context.Context, m Map) (int, error) {
+			return part2(ctx, m.directions, m.nodes)
+		},
+	})
 }
 
-func part1(directions []Direction, nodeMap map[NodeAddress]NodeChoice) int {
+func part1(ctx context.Context, directions []Direction, nodeMap map[NodeAddress]NodeChoice) (int, error) {
 	const (
 		NodeAddressStart NodeAddress = "AAA"
 		NodeAddressEnd   NodeAddress = "ZZZ"
@@ -84,29 +69,48 @@ func part1(directions []Direction, nodeMap map[NodeAddress]NodeChoice) int {
 	steps := 0
 
 	for currentNode != NodeAddressEnd {
+		// If the nodes loop without reaching the end, we'd walk forever; give up if we've been told to
+		if err := checkContext(ctx, directionCursor); err != nil {
+			return 0, err
+		}
+
+		choice, ok := nodeMap[currentNode]
+		if !ok {
+			return 0, fmt.Errorf("%w: %s", ErrMissingNode, currentNode)
+		}
+
 		direction := directions[directionCursor]
-		currentNode = nodeMap[currentNode].TakeDirection(direction)
+		currentNode = choice.TakeDirection(direction)
 		directionCursor = (directionCursor + 1) % len(directions)
 		steps++
 	}
 
-	return steps
+	return steps, nil
 }
 
-func part2(directions []Direction, nodeMap map[NodeAddress]NodeChoice) int {
+func part2(ctx context.Context, directions []Direction, nodeMap map[NodeAddress]NodeChoice) (int, error) {
 	directionCursor := 0
 	nodes := findPart2StartingNodes(nodeMap)
 	if len(nodes) == 0 {
-		panic("no starting nodes")
+		return 0, ErrNoStartingNode
 	}
 
 	steps := 0
 	encounteredEnd := []int{}
 
 	for len(encounteredEnd) != len(nodes) {
+		if err := checkContext(ctx, directionCursor); err != nil {
+			return 0, err
+		}
+
 		direction := directions[directionCursor]
 		for i, node := range nodes {
-			nodes[i] = nodeMap[node].TakeDirection(direction)
+			choice, ok := nodeMap[node]
+			if !ok {
+				return 0, fmt.Errorf("%w: %s", ErrMissingNode, node)
+			}
+
+			nodes[i] = choice.TakeDirection(direction)
 			if nodeEndsIn(nodes[i], 'Z') {
 				encounteredEnd = append(encounteredEnd, steps+1)
 			}
@@ -117,7 +121,17 @@ func part2(directions []Direction, nodeMap map[NodeAddress]NodeChoice) int {
 	}
 
 	// Once we have encountered all the steps to get to each ending, the LCM will find the first time they all match
-	return sliceLCM(encounteredEnd)
+	return sliceLCM(encounteredEnd), nil
+}
+
+// checkContext gets the context's error, if it is done. It is only checked once per pass through the directions, so
+// that it is cheap to call on every step.
+func checkContext(ctx context.Context, directionCursor int) error {
+	if directionCursor != 0 {
+		return nil
+	}
+
+	return ctx.Err()
 }
 
 // sliceLCM finds the LCM of the numbers in the given slice. Panics if the slice is of length zero
@@ -166,6 +180,26 @@ func nodeEndsIn(addr NodeAddress, c byte) bool {
 	return addr[len(addr)-1] == c
 }
 
+func parseInput(input string) (Map, error) {
+	inputLines := strings.Split(input, "\n")
+	if len(inputLines) < 3 {
+		return Map{}, errors.New("not enough data in input to parse")
+	}
+
+	directions, err := parseDirectionLine(inputLines[0])
+	if err != nil {
+		return Map{}, parse.AtLine(fmt.Errorf("parse direction line: %w", err), 1)
+	}
+
+	nodeMap, err := parseMap(inputLines[2:])
+	if err != nil {
+		// The map starts after the directions and the blank line that follows them
+		return Map{}, parse.AtLine(fmt.Errorf("parse map: %w", err), 3)
+	}
+
+	return Map{directions: directions, nodes: nodeMap}, nil
+}
+
 func parseDirectionLine(line string) ([]Direction, error) {
 	directions := make([]Direction, len(line))
 	for i, char := range line {
@@ -174,7 +208,7 @@ func parseDirectionLine(line string) ([]Direction, error) {
 		} else if char == 'R' {
 			directions[i] = DirectionRight
 		} else {
-			return nil, fmt.Errorf("invalid direction char '%c'", char)
+			return nil, parse.AtColumn(fmt.Errorf("invalid direction char '%c'", char), i+1)
 		}
 	}
 
@@ -183,10 +217,10 @@ func parseDirectionLine(line string) ([]Direction, error) {
 
 func parseMap(lines []string) (map[NodeAddress]NodeChoice, error) {
 	mapNodes := make(map[NodeAddress]NodeChoice, len(lines))
-	for _, line := range lines {
+	for i, line := range lines {
 		source, choice, err := parseMapLine(line)
 		if err != nil {
-			return nil, fmt.Errorf("could not parse %q: %w", line, err)
+			return nil, parse.AtLine(fmt.Errorf("could not parse %q: %w", line, err), i+1)
 		}
 
 		mapNodes[source] = choice
@@ -197,13 +231,13 @@ func parseMap(lines []string) (map[NodeAddress]NodeChoice, error) {
 
 func parseMapLine(line string) (NodeAddress, NodeChoice, error) {
 	pattern := regexp.MustCompile(`^([0-9A-Z]{2}[A-Z]) = \(([0-9A-Z]{2}[A-Z]), ([0-9A-Z]{2}[A-Z])\)$`)
-	matches := pattern.FindStringSubmatch(line)
-	if matches == nil {
-		return "", NodeChoice{}, errors.New("malformed line")
+	captures, err := parse.Match(pattern, line)
+	if err != nil {
+		return "", NodeChoice{}, fmt.Errorf("malformed line: %w", err)
 	}
 
-	source := NodeAddress(matches[1])
-	choice := NodeChoice{left: NodeAddress(matches[2]), right: NodeAddress(matches[3])}
+	source := NodeAddress(captures.Group(1))
+	choice := NodeChoice{left: NodeAddress(captures.Group(2)), right: NodeAddress(captures.Group(3))}
 
 	return source, choice, nil
 }
@@ -0,0 +1,68 @@
+package day9
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/ollien/advent-of-code-2023/aoc"
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+func init() {
+	aoc.Register(9, aoc.Solution[[][]int, int]{
+		Parse: func(input string) ([][]int, error) {
+			return parse.Lines(input, parseHistory)
+		},
+		Part1: part1,
+		Part2: part2,
+	})
+}
+
+func part1(_ context.Context, histories [][]int) (int, error) {
+	total := 0
+	for _, history := range histories {
+		total += predictNextValue(history)
+	}
+
+	return total, nil
+}
+
+func part2(_ context.Context, histories [][]int) (int, error) {
+	total := 0
+	for _, history := range histories {
+		reversedHistory := slices.Clone(history)
+		slices.Reverse(reversedHistory)
+		total += predictNextValue(reversedHistory)
+	}
+
+	return total, nil
+}
+
+// predictNextValue calculates the next item in the sequence by using the nth differences
+func predictNextValue(history []int) int {
+	if allEqual(history, 0) {
+		return 0
+	}
+
+	differences := make([]int, len(history)-1)
+	for i := range history[1:] {
+		differences[i] = history[i+1] - history[i]
+	}
+
+	return history[len(history)-1] + predictNextValue(differences)
+}
+
+func allEqual[T comparable, S ~[]T](values S, shouldEqual T) bool {
+	for _, value := range values {
+		if value != shouldEqual {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseHistory(line string) ([]int, error) {
+	return parse.Fields(line, strconv.Atoi)
+}
@@ -0,0 +1,335 @@
+// Package grid provides a two dimensional grid of tiles, as used by many of the puzzles.
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+var ErrUnevenRows = errors.New("rows have uneven lengths")
+
+// Coordinate is a position in a grid. Rows increase going south, and columns increase going east.
+type Coordinate struct {
+	Row int
+	Col int
+}
+
+// Grid is a rectangular grid of tiles. The zero value is an empty grid.
+type Grid[T any] struct {
+	tiles [][]T
+}
+
+func (coordinate Coordinate) North() Coordinate {
+	return Coordinate{Row: coordinate.Row - 1, Col: coordinate.Col}
+}
+
+func (coordinate Coordinate) South() Coordinate {
+	return Coordinate{Row: coordinate.Row + 1, Col: coordinate.Col}
+}
+
+func (coordinate Coordinate) East() Coordinate {
+	return Coordinate{Row: coordinate.Row, Col: coordinate.Col + 1}
+}
+
+func (coordinate Coordinate) West() Coordinate {
+	return Coordinate{Row: coordinate.Row, Col: coordinate.Col - 1}
+}
+
+// Add gets the coordinate offset from this one by the given coordinate
+func (coordinate Coordinate) Add(offset Coordinate) Coordinate {
+	return Coordinate{Row: coordinate.Row + offset.Row, Col: coordinate.Col + offset.Col}
+}
+
+// ManhattanDistance gets the taxicab distance between the two coordinates
+func (coordinate Coordinate) ManhattanDistance(other Coordinate) int {
+	return abs(coordinate.Row-other.Row) + abs(coordinate.Col-other.Col)
+}
+
+// CardinalNeighbors gets all the neighbors of the given coordinate in the cardinal directions (north, south, east,
+// then west)
+func (coordinate Coordinate) CardinalNeighbors() []Coordinate {
+	return []Coordinate{
+		coordinate.North(),
+		coordinate.South(),
+		coordinate.East(),
+		coordinate.West(),
+	}
+}
+
+// DiagonalNeighbors gets all the neighbors of the given coordinate in the diagonal directions
+func (coordinate Coordinate) DiagonalNeighbors() []Coordinate {
+	return []Coordinate{
+		{Row: coordinate.Row - 1, Col: coordinate.Col - 1},
+		{Row: coordinate.Row - 1, Col: coordinate.Col + 1},
+		{Row: coordinate.Row + 1, Col: coordinate.Col - 1},
+		{Row: coordinate.Row + 1, Col: coordinate.Col + 1},
+	}
+}
+
+// AllNeighbors gets all the neighbors of the given coordinate in all directions. The cardinal neighbors come first.
+func (coordinate Coordinate) AllNeighbors() []Coordinate {
+	return append(coordinate.CardinalNeighbors(), coordinate.DiagonalNeighbors()...)
+}
+
+// Bounds finds the smallest box that contains all of the given coordinates, as its top-left and bottom-right
+// corners (inclusive). Returns two zero-valued coordinates if no coordinates are given.
+func Bounds(coordinates []Coordinate) (Coordinate, Coordinate) {
+	if len(coordinates) == 0 {
+		return Coordinate{}, Coordinate{}
+	}
+
+	minCorner := Coordinate{Row: math.MaxInt, Col: math.MaxInt}
+	maxCorner := Coordinate{Row: math.MinInt, Col: math.MinInt}
+	for _, coordinate := range coordinates {
+		minCorner.Row = min(coordinate.Row, minCorner.Row)
+		minCorner.Col = min(coordinate.Col, minCorner.Col)
+		maxCorner.Row = max(coordinate.Row, maxCorner.Row)
+		maxCorner.Col = max(coordinate.Col, maxCorner.Col)
+	}
+
+	return minCorner, maxCorner
+}
+
+// New makes a grid of the given size, with every tile set to its zero value
+func New[T any](height, width int) Grid[T] {
+	tiles := make([][]T, height)
+	for row := range tiles {
+		tiles[row] = make([]T, width)
+	}
+
+	return Grid[T]{tiles: tiles}
+}
+
+// FromRows makes a grid from the given rows of tiles, which must all be the same length. The rows are not copied.
+func FromRows[T any](rows [][]T) (Grid[T], error) {
+	for _, row := range rows {
+		if len(row) != len(rows[0]) {
+			return Grid[T]{}, ErrUnevenRows
+		}
+	}
+
+	return Grid[T]{tiles: rows}, nil
+}
+
+// Parse makes a grid from the given lines, using parseTile to convert each character to a tile
+func Parse[T any](lines []string, parseTile func(rune) (T, error)) (Grid[T], error) {
+	rows := make([][]T, len(lines))
+	for row, line := range lines {
+		// Each character is a tile, so rows are measured in runes, not bytes
+		chars := []rune(line)
+		if len(chars) != utf8.RuneCountInString(lines[0]) {
+			return Grid[T]{}, parse.AtLine(ErrUnevenRows, row+1)
+		}
+
+		rows[row] = make([]T, 0, len(chars))
+		for col, char := range chars {
+			tile, err := parseTile(char)
+			if err != nil {
+				return Grid[T]{}, parse.AtLine(parse.AtColumn(err, col+1), row+1)
+			}
+
+			rows[row] = append(rows[row], tile)
+		}
+	}
+
+	return FromRows(rows)
+}
+
+func (grid Grid[T]) Height() int {
+	return len(grid.tiles)
+}
+
+func (grid Grid[T]) Width() int {
+	if len(grid.tiles) == 0 {
+		return 0
+	}
+
+	return len(grid.tiles[0])
+}
+
+// InBounds checks if the given position is in bounds of the grid
+func (grid Grid[T]) InBounds(position Coordinate) bool {
+	return position.Row >= 0 && position.Col >= 0 && position.Row < grid.Height() && position.Col < grid.Width()
+}
+
+// At gets the tile at the given position. Panics if the position is out of bounds.
+func (grid Grid[T]) At(position Coordinate) T {
+	return grid.tiles[position.Row][position.Col]
+}
+
+// Lookup gets the tile at the given position, if it is in bounds
+func (grid Grid[T]) Lookup(position Coordinate) (T, bool) {
+	if !grid.InBounds(position) {
+		return *new(T), false
+	}
+
+	return grid.At(position), true
+}
+
+// Set sets the tile at the given position. Panics if the position is out of bounds.
+func (grid Grid[T]) Set(position Coordinate, tile T) {
+	grid.tiles[position.Row][position.Col] = tile
+}
+
+// Wrap maps the given position, which may be out of bounds, onto the grid, as if the grid repeated infinitely
+// in every direction.
+func (grid Grid[T]) Wrap(position Coordinate) Coordinate {
+	return Coordinate{
+		Row: mod(position.Row, grid.Height()),
+		Col: mod(position.Col, grid.Width()),
+	}
+}
+
+// AtWrapped gets the tile at the given position, as if the grid repeated infinitely in every direction
+func (grid Grid[T]) AtWrapped(position Coordinate) T {
+	return grid.At(grid.Wrap(position))
+}
+
+// CardinalNeighbors gets the cardinal neighbors of the given position that are in bounds
+func (grid Grid[T]) CardinalNeighbors(position Coordinate) []Coordinate {
+	return grid.filterInBounds(position.CardinalNeighbors())
+}
+
+// AllNeighbors gets the neighbors of the given position in all directions that are in bounds
+func (grid Grid[T]) AllNeighbors(position Coordinate) []Coordinate {
+	return grid.filterInBounds(position.AllNeighbors())
+}
+
+// WrappedNeighbors gets the cardinal neighbors of the given position, wrapped around the edges of the grid
+func (grid Grid[T]) WrappedNeighbors(position Coordinate) []Coordinate {
+	neighbors := position.CardinalNeighbors()
+	for i, neighbor := range neighbors {
+		neighbors[i] = grid.Wrap(neighbor)
+	}
+
+	return neighbors
+}
+
+// Coordinates gets every position in the grid, in row-major order
+func (grid Grid[T]) Coordinates() []Coordinate {
+	return grid.FindAll(func(T) bool { return true })
+}
+
+// FindAll gets the positions of every tile that satisfies the given predicate, in row-major order
+func (grid Grid[T]) FindAll(predicate func(T) bool) []Coordinate {
+	found := []Coordinate{}
+	for row, rowTiles := range grid.tiles {
+		for col, tile := range rowTiles {
+			if predicate(tile) {
+				found = append(found, Coordinate{Row: row, Col: col})
+			}
+		}
+	}
+
+	return found
+}
+
+// Row gets a copy of the tiles in the given row. Panics if the row is out of bounds.
+func (grid Grid[T]) Row(row int) []T {
+	res := make([]T, grid.Width())
+	copy(res, grid.tiles[row])
+
+	return res
+}
+
+// Clone makes a copy of the grid, which can be modified independently of the original
+func (grid Grid[T]) Clone() Grid[T] {
+	return grid.remap(grid.Height(), grid.Width(), func(position Coordinate) Coordinate {
+		return position
+	})
+}
+
+// Transpose gets a copy of the grid flipped across its main diagonal, so that rows become columns
+func (grid Grid[T]) Transpose() Grid[T] {
+	return grid.remap(grid.Width(), grid.Height(), func(position Coordinate) Coordinate {
+		return Coordinate{Row: position.Col, Col: position.Row}
+	})
+}
+
+// RotateClockwise gets a copy of the grid rotated a quarter turn clockwise
+func (grid Grid[T]) RotateClockwise() Grid[T] {
+	height := grid.Height()
+	return grid.remap(grid.Width(), height, func(position Coordinate) Coordinate {
+		return Coordinate{Row: height - 1 - position.Col, Col: position.Row}
+	})
+}
+
+// RotateCounterClockwise gets a copy of the grid rotated a quarter turn counter-clockwise
+func (grid Grid[T]) RotateCounterClockwise() Grid[T] {
+	width := grid.Width()
+	return grid.remap(width, grid.Height(), func(position Coordinate) Coordinate {
+		return Coordinate{Row: position.Col, Col: width - 1 - position.Row}
+	})
+}
+
+// Fprint prints the grid to the given writer, one row per line, using formatTile to render each tile
+func (grid Grid[T]) Fprint(w io.Writer, formatTile func(Coordinate, T) string) error {
+	for row, rowTiles := range grid.tiles {
+		line := strings.Builder{}
+		for col, tile := range rowTiles {
+			line.WriteString(formatTile(Coordinate{Row: row, Col: col}, tile))
+		}
+
+		line.WriteByte('\n')
+		if _, err := io.WriteString(w, line.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// String renders the grid one row per line, formatting each tile with fmt.Sprint
+func (grid Grid[T]) String() string {
+	res := strings.Builder{}
+	// Writing to a strings.Builder can't fail
+	_ = grid.Fprint(&res, func(_ Coordinate, tile T) string {
+		return fmt.Sprint(tile)
+	})
+
+	return strings.TrimSuffix(res.String(), "\n")
+}
+
+// remap makes a new grid of the given size, where each position is filled from the position in this grid that
+// sourcePosition gives
+func (grid Grid[T]) remap(height, width int, sourcePosition func(Coordinate) Coordinate) Grid[T] {
+	res := New[T](height, width)
+	for row := 0; row < height; row++ {
+		for col := 0; col < width; col++ {
+			position := Coordinate{Row: row, Col: col}
+			res.Set(position, grid.At(sourcePosition(position)))
+		}
+	}
+
+	return res
+}
+
+func (grid Grid[T]) filterInBounds(positions []Coordinate) []Coordinate {
+	res := make([]Coordinate, 0, len(positions))
+	for _, position := range positions {
+		if grid.InBounds(position) {
+			res = append(res, position)
+		}
+	}
+
+	return res
+}
+
+// mod is like %, but always gives a non-negative result for a positive divisor
+func mod(n, divisor int) int {
+	return ((n % divisor) + divisor) % divisor
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
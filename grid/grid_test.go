@@ -0,0 +1,229 @@
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ollien/advent-of-code-2023/parse"
+)
+
+type stringerTile int
+
+func (tile stringerTile) String() string {
+	return fmt.Sprintf("<%d>", int(tile))
+}
+
+func parseRunes(t *testing.T, lines ...string) Grid[rune] {
+	t.Helper()
+
+	grid, err := Parse(lines, func(r rune) (rune, error) { return r, nil })
+	if err != nil {
+		t.Fatalf("failed to parse grid: %s", err)
+	}
+
+	return grid
+}
+
+func render(grid Grid[rune]) string {
+	out := strings.Builder{}
+	_ = grid.Fprint(&out, func(_ Coordinate, tile rune) string { return string(tile) })
+
+	return out.String()
+}
+
+func TestParseReadsTilesInRowMajorOrder(t *testing.T) {
+	grid := parseRunes(t, "ab", "cd", "ef")
+
+	if grid.Height() != 3 || grid.Width() != 2 {
+		t.Fatalf("Got %dx%d grid, not 3x2", grid.Height(), grid.Width())
+	}
+
+	if grid.At(Coordinate{Row: 2, Col: 1}) != 'f' {
+		t.Fatalf("Got %c at (2, 1), not f", grid.At(Coordinate{Row: 2, Col: 1}))
+	}
+}
+
+func TestParseRejectsUnevenRows(t *testing.T) {
+	_, err := Parse([]string{"abc", "de"}, func(r rune) (rune, error) { return r, nil })
+	if !errors.Is(err, ErrUnevenRows) {
+		t.Fatalf("Got error %v, not ErrUnevenRows", err)
+	}
+}
+
+func TestParseMeasuresRowsInCharacters(t *testing.T) {
+	grid := parseRunes(t, "é.", "..")
+	if grid.Height() != 2 || grid.Width() != 2 {
+		t.Fatalf("Got %dx%d grid, not 2x2", grid.Height(), grid.Width())
+	}
+
+	_, err := Parse([]string{"é.", "..."}, func(r rune) (rune, error) { return r, nil })
+	if !errors.Is(err, ErrUnevenRows) {
+		t.Fatalf("Got error %v, not ErrUnevenRows", err)
+	}
+}
+
+func TestParseReportsPositionOfBadTile(t *testing.T) {
+	errBadTile := errors.New("bad tile")
+	_, err := Parse([]string{"..", ".x"}, func(r rune) (rune, error) {
+		if r == 'x' {
+			return 0, errBadTile
+		}
+
+		return r, nil
+	})
+
+	var parseErr *parse.Error
+	if !errors.Is(err, errBadTile) {
+		t.Fatalf("Got error %v, not the tile's error", err)
+	} else if !errors.As(err, &parseErr) || parseErr.Line != 2 || parseErr.Column != 2 {
+		t.Fatalf("Error %v does not have the tile's position", err)
+	}
+}
+
+func TestLookupOutOfBounds(t *testing.T) {
+	grid := parseRunes(t, "ab", "cd")
+
+	for _, position := range []Coordinate{{Row: -1, Col: 0}, {Row: 0, Col: 2}, {Row: 2, Col: 0}} {
+		if _, ok := grid.Lookup(position); ok {
+			t.Fatalf("Lookup of %+v was in bounds", position)
+		}
+	}
+
+	if tile, ok := grid.Lookup(Coordinate{Row: 1, Col: 0}); !ok || tile != 'c' {
+		t.Fatalf("Got (%c, %t) for (1, 0), not (c, true)", tile, ok)
+	}
+}
+
+func TestCardinalNeighborsExcludeOutOfBounds(t *testing.T) {
+	grid := parseRunes(t, "ab", "cd")
+
+	neighbors := grid.CardinalNeighbors(Coordinate{Row: 0, Col: 0})
+	expected := []Coordinate{{Row: 1, Col: 0}, {Row: 0, Col: 1}}
+	if !slices.Equal(neighbors, expected) {
+		t.Fatalf("Got neighbors %v, not %v", neighbors, expected)
+	}
+}
+
+func TestAllNeighborsIncludeDiagonals(t *testing.T) {
+	grid := parseRunes(t, "abc", "def", "ghi")
+
+	neighbors := grid.AllNeighbors(Coordinate{Row: 1, Col: 1})
+	if len(neighbors) != 8 {
+		t.Fatalf("Got %d neighbors, not 8", len(neighbors))
+	}
+
+	cornerNeighbors := grid.AllNeighbors(Coordinate{Row: 2, Col: 2})
+	if len(cornerNeighbors) != 3 {
+		t.Fatalf("Got %d corner neighbors, not 3", len(cornerNeighbors))
+	}
+}
+
+func TestWrapMapsNegativeCoordinatesOntoGrid(t *testing.T) {
+	grid := parseRunes(t, "abc", "def")
+
+	wrapped := grid.Wrap(Coordinate{Row: -1, Col: -4})
+	if wrapped != (Coordinate{Row: 1, Col: 2}) {
+		t.Fatalf("Got %+v, not (1, 2)", wrapped)
+	}
+
+	if grid.AtWrapped(Coordinate{Row: 4, Col: 3}) != 'a' {
+		t.Fatalf("Got %c at wrapped (4, 3), not a", grid.AtWrapped(Coordinate{Row: 4, Col: 3}))
+	}
+}
+
+func TestWrappedNeighborsStayInBounds(t *testing.T) {
+	grid := parseRunes(t, "abc", "def")
+
+	neighbors := grid.WrappedNeighbors(Coordinate{Row: 0, Col: 0})
+	expected := []Coordinate{{Row: 1, Col: 0}, {Row: 1, Col: 0}, {Row: 0, Col: 1}, {Row: 0, Col: 2}}
+	if !slices.Equal(neighbors, expected) {
+		t.Fatalf("Got neighbors %v, not %v", neighbors, expected)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	grid := parseRunes(t, "abc", "def")
+
+	transposed := grid.Transpose()
+	if render(transposed) != "ad\nbe\ncf\n" {
+		t.Fatalf("Got transposed grid\n%s", render(transposed))
+	}
+}
+
+func TestRotateClockwise(t *testing.T) {
+	grid := parseRunes(t, "abc", "def")
+
+	rotated := grid.RotateClockwise()
+	if render(rotated) != "da\neb\nfc\n" {
+		t.Fatalf("Got rotated grid\n%s", render(rotated))
+	}
+}
+
+func TestRotateCounterClockwiseUndoesClockwise(t *testing.T) {
+	grid := parseRunes(t, "abc", "def")
+
+	rotated := grid.RotateClockwise().RotateCounterClockwise()
+	if render(rotated) != render(grid) {
+		t.Fatalf("Got grid\n%s\nafter rotating back and forth", render(rotated))
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	grid := parseRunes(t, "ab", "cd")
+
+	clone := grid.Clone()
+	clone.Set(Coordinate{Row: 0, Col: 0}, 'z')
+	if grid.At(Coordinate{Row: 0, Col: 0}) != 'a' {
+		t.Fatalf("Setting a tile in the clone modified the original")
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	grid := parseRunes(t, "#.", ".#")
+
+	found := grid.FindAll(func(r rune) bool { return r == '#' })
+	expected := []Coordinate{{Row: 0, Col: 0}, {Row: 1, Col: 1}}
+	if !slices.Equal(found, expected) {
+		t.Fatalf("Got %v, not %v", found, expected)
+	}
+}
+
+func TestFprintOverlaysUsingPosition(t *testing.T) {
+	grid := parseRunes(t, "ab", "cd")
+
+	out := strings.Builder{}
+	err := grid.Fprint(&out, func(position Coordinate, tile rune) string {
+		if position == (Coordinate{Row: 1, Col: 1}) {
+			return "*"
+		}
+
+		return string(tile)
+	})
+
+	if err != nil {
+		t.Fatalf("Failed to print: %s", err)
+	} else if out.String() != "ab\nc*\n" {
+		t.Fatalf("Got output %q", out.String())
+	}
+}
+
+func TestStringUsesTileStringer(t *testing.T) {
+	grid, err := FromRows([][]stringerTile{{1, 2}, {3, 4}})
+	if err != nil {
+		t.Fatalf("failed to make grid: %s", err)
+	}
+
+	if grid.String() != "<1><2>\n<3><4>" {
+		t.Fatalf("Got %q", grid.String())
+	}
+}
+
+func TestBounds(t *testing.T) {
+	minCorner, maxCorner := Bounds([]Coordinate{{Row: 3, Col: -1}, {Row: -2, Col: 5}, {Row: 0, Col: 0}})
+	if minCorner != (Coordinate{Row: -2, Col: -1}) || maxCorner != (Coordinate{Row: 3, Col: 5}) {
+		t.Fatalf("Got bounds %+v to %+v", minCorner, maxCorner)
+	}
+}
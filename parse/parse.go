@@ -0,0 +1,239 @@
+// Package parse provides helpers for parsing puzzle input, which keep track of where in the input any errors occurred.
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var ErrNoMatch = errors.New("does not match pattern")
+
+// Error is an error that occurred at a specific position in the input. Lines and columns start at one, and are zero if
+// they are not known. The position is not included in the error's message, as it is adjusted as the error passes back
+// up through the parsing helpers, after any context has been added to the message; use InFile to describe it.
+type Error struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+// Captures holds the groups that were captured when matching a pattern against a string
+type Captures struct {
+	s       string
+	indices []int
+}
+
+func (err *Error) Error() string {
+	return err.Err.Error()
+}
+
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// AtLine marks the given error as having occurred within the piece of input that starts at the given line. If the
+// error already has a line, it is taken to be relative to that piece of input.
+func AtLine(err error, line int) error {
+	return locate(err, func(parseErr *Error) {
+		parseErr.Line = line + max(parseErr.Line, 1) - 1
+	})
+}
+
+// AtColumn marks the given error as having occurred within the piece of a line that starts at the given column. If
+// the error already has a column, it is taken to be relative to that piece of the line.
+func AtColumn(err error, column int) error {
+	return locate(err, func(parseErr *Error) {
+		parseErr.Column = column + max(parseErr.Column, 1) - 1
+	})
+}
+
+// InFile describes where in the given file err occurred, as precisely as is known
+func InFile(err error, filename string) error {
+	var parseErr *Error
+	if !errors.As(err, &parseErr) || parseErr.Line == 0 {
+		return fmt.Errorf("%s: %w", filename, err)
+	} else if parseErr.Column == 0 {
+		return fmt.Errorf("%s:%d: %w", filename, parseErr.Line, err)
+	}
+
+	return fmt.Errorf("%s:%d:%d: %w", filename, parseErr.Line, parseErr.Column, err)
+}
+
+// Each parses every one of the given items, which do not have a known position in the input
+func Each[T any](items []string, parseItem func(string) (T, error)) ([]T, error) {
+	res := make([]T, 0, len(items))
+	for i, item := range items {
+		parsed, err := parseItem(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid item #%d: %w", i+1, err)
+		}
+
+		res = append(res, parsed)
+	}
+
+	return res, nil
+}
+
+// Lines parses every line of the given input
+func Lines[T any](input string, parseLine func(string) (T, error)) ([]T, error) {
+	lines := strings.Split(input, "\n")
+	res := make([]T, 0, len(lines))
+	for i, line := range lines {
+		parsed, err := parseLine(line)
+		if err != nil {
+			return nil, AtLine(err, i+1)
+		}
+
+		res = append(res, parsed)
+	}
+
+	return res, nil
+}
+
+// Sections parses every section of the given input, where sections are separated by a blank line
+func Sections[T any](input string, parseSection func(string) (T, error)) ([]T, error) {
+	sections := strings.Split(input, "\n\n")
+	res := make([]T, 0, len(sections))
+	line := 1
+	for _, section := range sections {
+		parsed, err := parseSection(section)
+		if err != nil {
+			return nil, AtLine(err, line)
+		}
+
+		res = append(res, parsed)
+		// Skip past the section and the blank line after it
+		line += strings.Count(section, "\n") + 2
+	}
+
+	return res, nil
+}
+
+// Split parses every item of the given line, where items are separated by sep
+func Split[T any](line string, sep string, parseItem func(string) (T, error)) ([]T, error) {
+	items := strings.Split(line, sep)
+	res := make([]T, 0, len(items))
+	offset := 0
+	for _, item := range items {
+		parsed, err := parseItem(item)
+		if err != nil {
+			return nil, AtColumn(err, columnOf(line, offset))
+		}
+
+		res = append(res, parsed)
+		offset += len(item) + len(sep)
+	}
+
+	return res, nil
+}
+
+// Fields parses every item of the given line, where items are separated by any amount of whitespace
+func Fields[T any](line string, parseItem func(string) (T, error)) ([]T, error) {
+	return fieldsFunc(line, unicode.IsSpace, parseItem)
+}
+
+// Ints parses all of the integers in the given line, which are separated by any amount of whitespace and/or commas
+func Ints(line string) ([]int, error) {
+	isSeparator := func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	}
+
+	return fieldsFunc(line, isSeparator, strconv.Atoi)
+}
+
+// Match matches the given pattern against s, returning ErrNoMatch if it does not match
+func Match(pattern *regexp.Regexp, s string) (Captures, error) {
+	indices := pattern.FindStringSubmatchIndex(s)
+	if indices == nil {
+		return Captures{}, fmt.Errorf("%w %s", ErrNoMatch, pattern)
+	}
+
+	return Captures{s: s, indices: indices}, nil
+}
+
+// Capture parses the given group of the captures. Any error is positioned at the start of the group.
+func Capture[T any](captures Captures, group int, parseGroup func(string) (T, error)) (T, error) {
+	parsed, err := parseGroup(captures.Group(group))
+	if err != nil {
+		return *new(T), AtColumn(err, captures.Column(group))
+	}
+
+	return parsed, nil
+}
+
+// Group gets the text captured by the given group, where group zero is the entire match. An empty string is returned
+// if the group did not participate in the match. Panics if the group does not exist in the pattern.
+func (captures Captures) Group(group int) string {
+	start, end := captures.indices[2*group], captures.indices[2*group+1]
+	if start == -1 {
+		return ""
+	}
+
+	return captures.s[start:end]
+}
+
+// Column gets the column at which the given group starts, relative to the matched string. Panics if the group does
+// not exist in the pattern.
+func (captures Captures) Column(group int) int {
+	return columnOf(captures.s, max(captures.indices[2*group], 0))
+}
+
+// Int parses the given group as an integer
+func (captures Captures) Int(group int) (int, error) {
+	return Capture(captures, group, strconv.Atoi)
+}
+
+// Ints parses all of the integers in the given group. See the Ints function for more details.
+func (captures Captures) Ints(group int) ([]int, error) {
+	return Capture(captures, group, Ints)
+}
+
+func fieldsFunc[T any](line string, isSeparator func(rune) bool, parseItem func(string) (T, error)) ([]T, error) {
+	res := []T{}
+	start := -1
+	// Iterate one past the end of the line, so that the last field is always terminated
+	for offset, char := range line + " " {
+		if !isSeparator(char) && offset < len(line) {
+			if start == -1 {
+				start = offset
+			}
+
+			continue
+		} else if start == -1 {
+			continue
+		}
+
+		parsed, err := parseItem(line[start:offset])
+		if err != nil {
+			return nil, AtColumn(err, columnOf(line, start))
+		}
+
+		res = append(res, parsed)
+		start = -1
+	}
+
+	return res, nil
+}
+
+// locate applies the given update to the position of the first *Error in err's chain, making one if none exists
+func locate(err error, updatePosition func(*Error)) error {
+	var parseErr *Error
+	if !errors.As(err, &parseErr) {
+		parseErr = &Error{Err: err}
+		err = parseErr
+	}
+
+	updatePosition(parseErr)
+
+	return err
+}
+
+// columnOf gets the (one-based) column of the given byte offset in the line
+func columnOf(line string, offset int) int {
+	return utf8.RuneCountInString(line[:offset]) + 1
+}
@@ -0,0 +1,115 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestLinesReportsLineOfError(t *testing.T) {
+	_, err := Lines("1\n2\nthree\n4", strconv.Atoi)
+
+	var parseErr *Error
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Got error %v, not a parse error", err)
+	} else if parseErr.Line != 3 {
+		t.Fatalf("Got line %d, not 3", parseErr.Line)
+	}
+}
+
+func TestSectionsReportsLineWithinSection(t *testing.T) {
+	input := "1 2\n3 4\n\n5 6\n7 x\n8 9"
+	_, err := Sections(input, func(section string) ([][]int, error) {
+		return Lines(section, Ints)
+	})
+
+	var parseErr *Error
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Got error %v, not a parse error", err)
+	} else if parseErr.Line != 5 || parseErr.Column != 3 {
+		t.Fatalf("Got position %d:%d, not 5:3", parseErr.Line, parseErr.Column)
+	}
+}
+
+func TestIntsAllowsMixedSeparators(t *testing.T) {
+	ints, err := Ints("  19, 13,30 -2  ")
+	if err != nil {
+		t.Fatalf("Failed to parse: %s", err)
+	}
+
+	expected := []int{19, 13, 30, -2}
+	if !slices.Equal(ints, expected) {
+		t.Fatalf("Got %v, not %v", ints, expected)
+	}
+}
+
+func TestSplitReportsColumnOfItem(t *testing.T) {
+	_, err := Split("ab, cd, e!", ", ", func(item string) (string, error) {
+		if item == "e!" {
+			return "", errors.New("bad item")
+		}
+
+		return item, nil
+	})
+
+	var parseErr *Error
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Got error %v, not a parse error", err)
+	} else if parseErr.Column != 9 {
+		t.Fatalf("Got column %d, not 9", parseErr.Column)
+	}
+}
+
+func TestMatchReportsNoMatch(t *testing.T) {
+	_, err := Match(regexp.MustCompile(`^\d+$`), "abc")
+	if !errors.Is(err, ErrNoMatch) {
+		t.Fatalf("Got error %v, not ErrNoMatch", err)
+	}
+}
+
+func TestCaptureReportsColumnOfGroup(t *testing.T) {
+	captures, err := Match(regexp.MustCompile(`^(\w+): (.*)$`), "seeds: 1 2 x 4")
+	if err != nil {
+		t.Fatalf("Failed to match: %s", err)
+	}
+
+	if captures.Group(1) != "seeds" {
+		t.Fatalf("Got group %q, not seeds", captures.Group(1))
+	}
+
+	_, err = captures.Ints(2)
+
+	var parseErr *Error
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Got error %v, not a parse error", err)
+	} else if parseErr.Column != 12 {
+		t.Fatalf("Got column %d, not 12", parseErr.Column)
+	}
+}
+
+func TestInFileDescribesPositionOfWrappedError(t *testing.T) {
+	_, err := Lines("1\n2 x", func(line string) (int, error) {
+		n, err := Fields(line, strconv.Atoi)
+		if err != nil {
+			return 0, fmt.Errorf("bad numbers: %w", err)
+		}
+
+		return n[0], nil
+	})
+
+	err = InFile(err, "input.txt")
+	expected := `input.txt:2:3: bad numbers: strconv.Atoi: parsing "x": invalid syntax`
+	if err.Error() != expected {
+		t.Fatalf("Got error %q, not %q", err, expected)
+	}
+}
+
+func TestInFileWithoutPosition(t *testing.T) {
+	err := InFile(errors.New("empty input"), "input.txt")
+	if err.Error() != "input.txt: empty input" {
+		t.Fatalf("Got error %q", err)
+	}
+}